@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/solver"
+	"os"
+	"strings"
+	"time"
+)
+
+// runBench прогоняет поиск доказательства по набору задач: по одной формуле или секвенции
+// в строке, строки с # - комментарии. Код завершения - худший из полученных.
+func runBench(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	usageFor(fs, "bench [flags] problem-file")
+
+	var system systemFlags
+	var syntax syntaxFlag
+	var search searchFlags
+	system.register(fs)
+	syntax.register(fs)
+	search.register(fs)
+	if !parseFlags(fs, args) {
+		return exitError
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	sys, err := system.load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	dialect, err := syntax.dialect()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	opts, err := search.options(expression.Printer{})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	library, err := search.loadLibrary(sys)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if library != nil {
+		opts = append(opts, solver.WithLibrary(library))
+	}
+
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	defer file.Close()
+
+	code := exitProved
+	counts := make(map[solver.Status]int)
+	var total time.Duration
+
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		premises, target, err := parseSequent(line, dialect)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %s\n", n, describeError(err))
+			code = exitError
+			continue
+		}
+
+		problemOpts := opts
+		if len(premises) > 0 {
+			problemOpts = append(problemOpts[:len(problemOpts):len(problemOpts)], solver.WithHypotheses(premises...))
+		}
+
+		status, steps, duration, err := benchProblem(sys, target, search.timeLimit(), problemOpts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", n, err)
+			code = exitError
+			continue
+		}
+
+		counts[status]++
+		total += duration
+		code = max(code, exitCode(status))
+		fmt.Printf("%-8s %10d %14s  %s\n", status, steps, duration.Round(time.Microsecond), line)
+	}
+	if err = scanner.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	fmt.Printf("\nproved %d, refuted %d, unknown %d, total time %s\n",
+		counts[solver.Proved], counts[solver.Refuted], counts[solver.Unknown], total.Round(time.Microsecond))
+	return code
+}
+
+// benchProblem решает одну задачу набора и возвращает статус, число шагов и время.
+func benchProblem(system axioms.System, target expression.Expression, timeLimit uint64,
+	opts []solver.Option) (solver.Status, uint64, time.Duration, error) {
+	slv, err := solver.New(system, target, timeLimit, opts...)
+	if err != nil {
+		return solver.Unknown, 0, 0, err
+	}
+	defer slv.Close()
+
+	start := time.Now()
+	if err = slv.WriteInitialAxioms(); err != nil {
+		return solver.Unknown, 0, 0, err
+	}
+
+	result := slv.Solve()
+	return result.Status, slv.Steps(), time.Since(start), nil
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunBench(t *testing.T) {
+	problems := filepath.Join(t.TempDir(), "problems.txt")
+	if err := os.WriteFile(problems, []byte("# problems\na>a\na, a>b |- b\na>b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var code int
+	output := captureStdout(t, func() { code = runBench([]string{"-timeout", "0", "-steps", "100000", problems}) })
+	// Худший результат набора - опровержение
+	if code != exitRefuted {
+		t.Fatalf("bench exited with %d, want %d", code, exitRefuted)
+	}
+	if !strings.Contains(output, "\nproved 2, refuted 1, unknown 0, total time ") {
+		t.Fatalf("bench printed %q", output)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/checker"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/tiendc/go-deepcopy"
+	"os"
+)
+
+// runCheck проверяет файл доказательства, записанный prove -format proof.
+func runCheck(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	usageFor(fs, "check [flags] proof-file")
+
+	var system systemFlags
+	var syntax syntaxFlag
+	system.register(fs)
+	syntax.register(fs)
+	goal := fs.String("goal", "", "formula or sequent (premises |- formula) the proof must prove; a file with another target is rejected")
+	hyps := fs.String("hyp", "", "comma-separated hypotheses the proof may use together with the premises of -goal")
+	if !parseFlags(fs, args) {
+		return exitError
+	}
+	if *hyps != "" && *goal == "" {
+		fmt.Fprintln(os.Stderr, "-hyp requires -goal")
+		return exitError
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	sys, err := system.load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	defer file.Close()
+
+	doc, err := proof.ReadDocument(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", fs.Arg(0), err)
+		return exitError
+	}
+
+	// Без -goal проверяется вывод цели файла из гипотез файла; с -goal гипотезы задаёт
+	// вызывающий, и шаги hyp с другими формулами должны быть сняты шагами deduction
+	hypotheses := doc.Hypotheses
+	if *goal != "" {
+		dialect, err := syntax.dialect()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		premises, requested, err := parseSequent(*goal, dialect)
+		if err != nil {
+			reportParseError(*goal, err)
+			return exitError
+		}
+		if *hyps != "" {
+			extra, err := parseList(*hyps, 0, dialect)
+			if err != nil {
+				reportParseError(*hyps, err)
+				return exitError
+			}
+			premises = append(premises, extra...)
+		}
+
+		if !sameFormula(requested, doc.Target) {
+			fmt.Printf("Rejected: the proof is for %s, not for %s\n", doc.Target.String(), requested.String())
+			return exitRefuted
+		}
+		hypotheses = premises
+	}
+
+	if err = checker.New(sys.Axioms, hypotheses...).Check(doc.Steps, doc.Target); err != nil {
+		fmt.Println("Rejected:", err)
+		return exitRefuted
+	}
+
+	fmt.Printf("Accepted: %s, %d steps\n", doc.Target.String(), len(doc.Steps))
+	return exitProved
+}
+
+// sameFormula сравнивает формулы в базисе импликации и отрицания.
+func sameFormula(lhs, rhs expression.Expression) bool {
+	var left, right expression.Expression
+	_ = deepcopy.Copy(&left, &lhs)
+	_ = deepcopy.Copy(&right, &rhs)
+	left.Standardize()
+	right.Standardize()
+	return left.String() == right.String()
+}
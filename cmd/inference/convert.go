@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var forms = []string{"none", "basis", "dnf", "cnf"}
+
+// runConvert переписывает формулу в другой нотации или нормальной форме.
+func runConvert(args []string) int {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	usageFor(fs, "convert [flags] formula")
+
+	var syntax syntaxFlag
+	var printing printerFlags
+	syntax.register(fs)
+	printing.register(fs)
+	form := fs.String("form", "none", "normal form: "+strings.Join(forms, ", ")+
+		"; basis rewrites the formula with implication and negation only")
+	if !parseFlags(fs, args) {
+		return exitError
+	}
+
+	dialect, err := syntax.dialect()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	printer, err := printing.printer()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	input := strings.Join(fs.Args(), " ")
+	if input == "" {
+		if input, err = readLine("Enter expression: "); err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading input:", err)
+			return exitError
+		}
+	}
+
+	expr, err := parseFormula(input, dialect)
+	if err != nil {
+		reportParseError(input, err)
+		return exitError
+	}
+
+	switch *form {
+	case "none":
+	case "basis":
+		expr.Standardize()
+	case "dnf":
+		expr, err = expr.DNF()
+	case "cnf":
+		expr, err = expr.CNF()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown form %q\n", *form)
+		return exitError
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	fmt.Println(printer.Print(&expr))
+	return exitProved
+}
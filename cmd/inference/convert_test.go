@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestRunConvert(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"-form", "basis", "a=b"}, exitProved, "(a>b)*(b>a)\n"},
+		{[]string{"-form", "dnf", "a>b"}, exitProved, "(!a*!b)|((!a*b)|(a*b))\n"},
+		{[]string{"-form", "cnf", "a>b"}, exitProved, "!a|b\n"},
+		{[]string{"-syntax", "ascii", "-notation", "unicode", "a -> b"}, exitProved, "a → b\n"},
+		{[]string{"-form", "nnf", "a>b"}, exitError, ""},
+	}
+
+	for _, tt := range tests {
+		var code int
+		output := captureStdout(t, func() { code = runConvert(tt.args) })
+		if code != tt.code || output != tt.want {
+			t.Errorf("convert %v = %d %q, want %d %q", tt.args, code, output, tt.code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/heuristic"
+	"github.com/spanwalla/logical-inference/internal/lemmas"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"github.com/spanwalla/logical-inference/internal/solver"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// systemFlags выбирает систему аксиом.
+type systemFlags struct {
+	preset string
+	file   string
+}
+
+func (f *systemFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.preset, "axioms", axioms.DefaultPreset, "axiom system: "+strings.Join(axioms.Presets(), ", "))
+	fs.StringVar(&f.file, "axioms-file", "", "file with user-defined axioms, one per line")
+}
+
+func (f *systemFlags) load() (axioms.System, error) {
+	if f.file != "" {
+		return axioms.Load(f.file)
+	}
+	return axioms.Preset(f.preset)
+}
+
+// syntaxFlag выбирает диалект ввода.
+type syntaxFlag struct {
+	name string
+}
+
+func (f *syntaxFlag) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.name, "syntax", logicparser.Any.Name, "input syntax: "+strings.Join(logicparser.DialectNames(), ", "))
+}
+
+func (f *syntaxFlag) dialect() (logicparser.Dialect, error) {
+	return logicparser.DialectByName(f.name)
+}
+
+// printerFlags настраивают печать формул.
+type printerFlags struct {
+	notation string
+	minimal  bool
+}
+
+func (f *printerFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.notation, "notation", expression.ClassicNotation.String(),
+		"output notation: "+strings.Join(expression.Notations(), ", "))
+	fs.BoolVar(&f.minimal, "minimal", false, "print formulas with as few parentheses as possible")
+}
+
+func (f *printerFlags) printer() (expression.Printer, error) {
+	notation, err := expression.NotationByName(f.notation)
+	return expression.Printer{Notation: notation, MinimalParentheses: f.minimal}, err
+}
+
+// searchFlags задают параметры поиска доказательства.
+type searchFlags struct {
+	timeout     time.Duration
+	steps       uint64
+	workers     int
+	strategy    string
+	subsumption bool
+	ageRatio    int
+	backward    int
+	rules       string
+	expand      bool
+	pure        bool
+	library     string
+}
+
+func (f *searchFlags) register(fs *flag.FlagSet) {
+	fs.DurationVar(&f.timeout, "timeout", time.Minute, "time limit for the proof search; 0 disables it if -steps is set, otherwise the limit is 1m")
+	fs.Uint64Var(&f.steps, "steps", 0, "limit on inference steps, 0 disables it")
+	fs.IntVar(&f.workers, "workers", runtime.GOMAXPROCS(0), "number of parallel workers")
+	fs.StringVar(&f.strategy, "heuristic", "",
+		"best-first search heuristic: "+strings.Join(heuristic.Names(), ", ")+"; empty for breadth-wise search")
+	fs.BoolVar(&f.subsumption, "subsumption", false, "discard derived formulas that are instances of known ones")
+	fs.IntVar(&f.ageRatio, "age-ratio", 4, "pick the oldest formula after every N heuristic picks, 0 disables it")
+	fs.IntVar(&f.backward, "backward", 0, "search backward from the target up to the given subgoal depth, 0 searches forward")
+	fs.StringVar(&f.rules, "rules", rules.ModusPonens{}.Name(),
+		"comma-separated inference rules: "+strings.Join(rules.Names(), ", "))
+	fs.BoolVar(&f.expand, "expand", false, "expand derived rule steps into modus ponens")
+	fs.BoolVar(&f.pure, "pure", false, "eliminate the deduction theorem and print a proof from the axioms only")
+	fs.StringVar(&f.library, "library", "", "lemma library file to load lemmas from and to save proved targets to")
+}
+
+// options собирает опции решателя, кроме библиотеки лемм: её загружает loadLibrary один раз
+// для нескольких решателей.
+func (f *searchFlags) options(printer expression.Printer) ([]solver.Option, error) {
+	opts := []solver.Option{solver.WithStepLimit(f.steps), solver.WithWorkers(f.workers), solver.WithPrinter(printer)}
+	if f.subsumption {
+		opts = append(opts, solver.WithSubsumption())
+	}
+
+	var enabled []rules.InferenceRule
+	for _, name := range strings.Split(f.rules, ",") {
+		rule, err := rules.ByName(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		enabled = append(enabled, rule)
+	}
+	opts = append(opts, solver.WithRules(enabled...))
+
+	if f.expand {
+		opts = append(opts, solver.WithExpansion())
+	}
+
+	if f.pure {
+		opts = append(opts, solver.WithDeductionElimination())
+	}
+
+	if f.backward > 0 {
+		opts = append(opts, solver.WithBackwardChaining(f.backward))
+	}
+
+	if f.strategy != "" {
+		h, err := heuristic.ByName(f.strategy)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, solver.WithHeuristic(h), solver.WithAgeRatio(f.ageRatio))
+	}
+	return opts, nil
+}
+
+// loadLibrary загружает библиотеку лемм из файла -library; без флага возвращает nil.
+func (f *searchFlags) loadLibrary(system axioms.System) (*lemmas.Library, error) {
+	if f.library == "" {
+		return nil, nil
+	}
+	return lemmas.Load(f.library, system)
+}
+
+// timeLimit возвращает ограничение по времени в миллисекундах.
+func (f *searchFlags) timeLimit() uint64 {
+	return uint64(f.timeout.Milliseconds())
+}
+
+// parseFlags разбирает флаги подкоманды; ошибка уже напечатана пакетом flag.
+func parseFlags(fs *flag.FlagSet, args []string) bool {
+	return fs.Parse(args) == nil
+}
+
+func usageFor(fs *flag.FlagSet, synopsis string) {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: inference %s\n", synopsis)
+		fs.PrintDefaults()
+	}
+}
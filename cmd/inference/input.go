@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseSequent разбирает ввод вида "посылка, посылка |- цель" (или с ⊢); без знака выводимости
+// весь ввод - цель. Позиция ошибки разбора отсчитывается от начала ввода.
+func parseSequent(input string, dialect logicparser.Dialect) ([]expression.Expression, expression.Expression, error) {
+	goal, offset := input, 0
+	var premises []expression.Expression
+
+	for _, turnstile := range []string{"⊢", "|-"} {
+		idx := strings.Index(input, turnstile)
+		if idx < 0 {
+			continue
+		}
+
+		var err error
+		if premises, err = parseList(input[:idx], 0, dialect); err != nil {
+			return nil, expression.Expression{}, err
+		}
+		goal, offset = input[idx+len(turnstile):], idx+len(turnstile)
+		break
+	}
+
+	target, err := parsePart(goal, offset, dialect)
+	return premises, target, err
+}
+
+// parseList разбирает формулы, перечисленные через запятую.
+func parseList(input string, offset int, dialect logicparser.Dialect) ([]expression.Expression, error) {
+	var result []expression.Expression
+	for _, part := range strings.Split(input, ",") {
+		expr, err := parsePart(part, offset, dialect)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expr)
+		offset += len(part) + 1
+	}
+	return result, nil
+}
+
+// parsePart разбирает часть ввода, начинающуюся с позиции offset, в формулу с константами.
+func parsePart(part string, offset int, dialect logicparser.Dialect) (expression.Expression, error) {
+	p := logicparser.NewLogicParserWithDialect(part, dialect)
+	parsed, err := p.Parse()
+	if err != nil {
+		var parseErr *logicparser.ParseError
+		if errors.As(err, &parseErr) {
+			shifted := *parseErr
+			shifted.Offset += offset
+			return expression.Expression{}, &shifted
+		}
+		return expression.Expression{}, err
+	}
+
+	parsed.MakeConst()
+	return *parsed, nil
+}
+
+// parseFormula разбирает одну формулу с константами.
+func parseFormula(input string, dialect logicparser.Dialect) (expression.Expression, error) {
+	return parsePart(input, 0, dialect)
+}
+
+// describeError возвращает текст ошибки; ошибки разбора описываются на английском.
+func describeError(err error) string {
+	var parseErr *logicparser.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Message(logicparser.English)
+	}
+	return err.Error()
+}
+
+// reportParseError печатает ошибку разбора и отмечает её позицию во вводе.
+func reportParseError(input string, err error) {
+	var parseErr *logicparser.ParseError
+	if errors.As(err, &parseErr) {
+		fmt.Fprintln(os.Stderr, "Error parsing expression:", parseErr.Message(logicparser.English))
+		fmt.Fprintln(os.Stderr, input)
+		fmt.Fprintln(os.Stderr, strings.Repeat(" ", utf8.RuneCountInString(input[:parseErr.Offset]))+"^")
+	} else {
+		fmt.Fprintln(os.Stderr, "Error parsing expression:", err)
+	}
+}
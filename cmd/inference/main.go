@@ -2,46 +2,79 @@ package main
 
 import (
 	"fmt"
-	"github.com/spanwalla/logical-inference/internal/expression"
-	"github.com/spanwalla/logical-inference/internal/logicparser"
 	"github.com/spanwalla/logical-inference/internal/solver"
-	"time"
+	"os"
+	"strings"
 )
 
+// Коды завершения различают итог поиска доказательства.
+const (
+	exitProved  = 0 // Доказано (check: доказательство принято)
+	exitRefuted = 1 // Опровергнуто (check: доказательство отклонено)
+	exitUnknown = 2 // Доказательство не найдено за отведённое время или число шагов
+	exitError   = 3 // Ошибка ввода, флагов или файлов
+)
+
+// command - подкоманда интерфейса командной строки.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands = []command{
+	{"prove", "search for a proof of a formula or a sequent", runProve},
+	{"check", "verify a proof file written by prove -format proof", runCheck},
+	{"table", "print the truth table of a formula", runTable},
+	{"convert", "rewrite a formula in another notation or normal form", runConvert},
+	{"bench", "run the prover on every problem of a problem set", runBench},
+	{"repl", "start an interactive session", runRepl},
+}
+
 func main() {
-	axioms := []expression.Expression{
-		*logicparser.NewExpressionWithString("a>(b>a)"),
-		*logicparser.NewExpressionWithString("(a>(b>c))>((a>b)>(a>c))"),
-		*logicparser.NewExpressionWithString("(!a>!b)>((!a>b)>a)"),
+	os.Exit(run(os.Args[1:]))
+}
+
+// run выбирает подкоманду. Без подкоманды (или с флагом первым аргументом) выполняется prove.
+func run(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") && args[0] != "-h" && args[0] != "-help" {
+		return runProve(args)
 	}
 
-	var input string
-	fmt.Print("Enter expression: ")
-	_, err := fmt.Scan(&input)
-	if err != nil {
-		fmt.Println("Error reading input:", err)
-		return
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
 	}
-	target := *logicparser.NewExpressionWithString(input)
-	target.Standardize()
-	target.MakeConst()
-
-	slv, err := solver.New(axioms, target, 60000)
-	if err != nil {
-		fmt.Println(err)
-		return
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		usage()
+		return exitProved
 	}
-	defer slv.Close()
 
-	start := time.Now()
-	err = slv.WriteInitialAxioms()
-	if err != nil {
-		fmt.Println(err)
-		return
+	fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+	usage()
+	return exitError
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: inference <command> [flags] [arguments]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.summary)
 	}
+	fmt.Fprintln(os.Stderr, "\nRun 'inference <command> -h' for the flags of a command.")
+	fmt.Fprintln(os.Stderr, "Exit codes: 0 proved, 1 refuted, 2 unknown, 3 error.")
+}
 
-	slv.Solve()
-	duration := time.Since(start)
-	fmt.Println(slv.ThoughtChain())
-	fmt.Println("Time elapsed:", duration)
+// exitCode переводит статус поиска в код завершения.
+func exitCode(status solver.Status) int {
+	switch status {
+	case solver.Proved:
+		return exitProved
+	case solver.Refuted:
+		return exitRefuted
+	default:
+		return exitUnknown
+	}
 }
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/spanwalla/logical-inference/internal/solver"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+)
+
+// runProve ищет доказательство формулы или секвенции, заданной аргументами или строкой stdin.
+func runProve(args []string) int {
+	fs := flag.NewFlagSet("prove", flag.ContinueOnError)
+	usageFor(fs, "prove [flags] [formula | premises |- formula]")
+
+	var system systemFlags
+	var syntax syntaxFlag
+	var printing printerFlags
+	var search searchFlags
+	system.register(fs)
+	syntax.register(fs)
+	printing.register(fs)
+	search.register(fs)
+	hyps := fs.String("hyp", "", "comma-separated premises, in addition to those before |-")
+	journal := fs.String("journal", "", "file to log every derivation to")
+	format := fs.String("format", "text", "output format: "+strings.Join(formats, ", "))
+	if !parseFlags(fs, args) {
+		return exitError
+	}
+
+	if !slices.Contains(formats, *format) {
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		return exitError
+	}
+
+	sys, err := system.load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	dialect, err := syntax.dialect()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	printer, err := printing.printer()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	input := strings.Join(fs.Args(), " ")
+	if input == "" {
+		if input, err = readLine("Enter expression: "); err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading input:", err)
+			return exitError
+		}
+	}
+
+	premises, target, err := parseSequent(input, dialect)
+	if err != nil {
+		reportParseError(input, err)
+		return exitError
+	}
+
+	if *hyps != "" {
+		extra, err := parseList(*hyps, 0, dialect)
+		if err != nil {
+			reportParseError(*hyps, err)
+			return exitError
+		}
+		premises = append(premises, extra...)
+	}
+
+	opts, err := search.options(printer)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	library, err := search.loadLibrary(sys)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if library != nil {
+		opts = append(opts, solver.WithLibrary(library))
+	}
+
+	if *journal != "" {
+		store, err := proof.NewFileStore(*journal)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		opts = append(opts, solver.WithStore(store))
+	}
+
+	if len(premises) > 0 {
+		opts = append(opts, solver.WithHypotheses(premises...))
+	}
+
+	slv, err := solver.New(sys, target, search.timeLimit(), opts...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	defer slv.Close()
+
+	start := time.Now()
+	if err = slv.WriteInitialAxioms(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result := slv.SolveContext(ctx)
+	duration := time.Since(start)
+
+	switch *format {
+	case "text":
+		fmt.Println(slv.ThoughtChain())
+		fmt.Println("Result:", result.Status)
+		fmt.Println("Time elapsed:", duration)
+	case "json":
+		if err = writeJSON(os.Stdout, slv.Proof()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+	default:
+		if result.Status == solver.Proved {
+			if err = writeProof(os.Stdout, *format, result, printer); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return exitError
+			}
+		}
+		fmt.Fprintln(os.Stderr, "Result:", result.Status)
+	}
+	return exitCode(result.Status)
+}
+
+var formats = []string{"text", "proof", "json", "dot", "tree"}
+
+// writeProof печатает доказательство в формате proof (читается командой check), dot или tree.
+func writeProof(w io.Writer, format string, result solver.Result, printer expression.Printer) error {
+	steps := proof.Linearize(result.Proof)
+	switch format {
+	case "proof":
+		return proof.WriteDocument(w, proof.Document{Hypotheses: result.Hypotheses, Target: result.Target, Steps: steps})
+	case "dot":
+		return proof.WriteDOT(w, steps, printer)
+	default:
+		return proof.WriteTree(w, steps, printer)
+	}
+}
+
+func writeJSON(w io.Writer, report solver.Proof) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(report)
+}
+
+// readLine выводит приглашение в stderr и читает строку из stdin.
+func readLine(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || input == "") {
+		return "", err
+	}
+	return strings.TrimSpace(input), nil
+}
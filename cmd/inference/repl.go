@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/lemmas"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/spanwalla/logical-inference/internal/solver"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// session - состояние интерактивного режима. Библиотека лемм строится один раз и общая
+// для всех поисков сессии.
+type session struct {
+	system     axioms.System
+	library    *lemmas.Library
+	dialect    logicparser.Dialect
+	printer    expression.Printer
+	search     searchFlags
+	hypotheses []expression.Expression
+
+	last   *solver.Solver // Решатель последнего поиска, закрывается перед следующим
+	result solver.Result
+}
+
+// replCommand - команда интерактивного режима.
+type replCommand struct {
+	name    string
+	args    string
+	summary string
+	run     func(s *session, arg string) error
+}
+
+var replCommands []replCommand
+
+func init() {
+	replCommands = []replCommand{
+		{"prove", "<formula | premises |- formula>", "search for a proof using the session hypotheses", (*session).prove},
+		{"show", "[text | proof | json | dot | tree]", "print the last proof", (*session).show},
+		{"hyp", "<formula>, ...", "add hypotheses to the session", (*session).addHypotheses},
+		{"hyps", "", "list the session hypotheses", (*session).listHypotheses},
+		{"clear", "[n]", "remove hypothesis n or all hypotheses", (*session).clearHypotheses},
+		{"axioms", "[preset | file <path>]", "show or change the axiom system", (*session).setAxioms},
+		{"lemma", "", "save the last proved goal to the lemma library", (*session).saveLemma},
+		{"lemmas", "", "list the lemma library", (*session).listLemmas},
+		{"known", "[n]", "show the formulas known to the last search, at most n (default 20)", (*session).listKnown},
+		{"set", "<flags>", "change search flags, e.g. set -timeout 5s -heuristic size", (*session).set},
+		{"help", "", "list the commands", (*session).help},
+	}
+}
+
+// runRepl запускает интерактивный режим: команды читаются построчно до quit или конца ввода.
+func runRepl(args []string) int {
+	fs := flag.NewFlagSet("repl", flag.ContinueOnError)
+	usageFor(fs, "repl [flags]")
+
+	var system systemFlags
+	var syntax syntaxFlag
+	var printing printerFlags
+	s := &session{}
+	system.register(fs)
+	syntax.register(fs)
+	printing.register(fs)
+	s.search.register(fs)
+	if !parseFlags(fs, args) {
+		return exitError
+	}
+
+	var err error
+	if s.system, err = system.load(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if s.dialect, err = syntax.dialect(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if s.printer, err = printing.printer(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if err = s.loadLibrary(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	defer s.closeLast()
+
+	fmt.Printf("Axiom system %s, %d lemmas. Type help for the list of commands.\n", s.system.Name, len(s.library.Lemmas()))
+	s.reportMissing()
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			break
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+		if name == "quit" || name == "exit" {
+			break
+		}
+
+		if err = s.execute(name, arg); err != nil {
+			fmt.Println("Error:", err)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	return exitProved
+}
+
+func (s *session) execute(name, arg string) error {
+	for _, cmd := range replCommands {
+		if cmd.name == name {
+			return cmd.run(s, arg)
+		}
+	}
+	return fmt.Errorf("unknown command %q, type help for the list of commands", name)
+}
+
+// loadLibrary строит библиотеку лемм текущей системы: из файла -library или из начальных лемм.
+func (s *session) loadLibrary() error {
+	library, err := s.search.loadLibrary(s.system)
+	if err == nil && library == nil {
+		library, err = lemmas.Bootstrap(s.system)
+	}
+	if err != nil {
+		return err
+	}
+	s.library = library
+	return nil
+}
+
+// reportMissing сообщает о начальных леммах, которые не удалось вывести в текущей системе.
+func (s *session) reportMissing() {
+	missing := s.library.Missing()
+	if len(missing) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(missing))
+	for i := range missing {
+		names = append(names, s.printer.Print(&missing[i]))
+	}
+	fmt.Println("Bootstrap lemmas were not derived:", strings.Join(names, ", "))
+}
+
+func (s *session) closeLast() {
+	if s.last != nil {
+		s.last.Close()
+		s.last = nil
+	}
+	s.result = solver.Result{}
+}
+
+func (s *session) prove(arg string) error {
+	if arg == "" {
+		return fmt.Errorf("usage: prove <formula | premises |- formula>")
+	}
+
+	premises, target, err := parseSequent(arg, s.dialect)
+	if err != nil {
+		return fmt.Errorf("%s", describeError(err))
+	}
+	premises = append(append([]expression.Expression{}, s.hypotheses...), premises...)
+
+	opts, err := s.search.options(s.printer)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, solver.WithLemmas(s.library))
+	if len(premises) > 0 {
+		opts = append(opts, solver.WithHypotheses(premises...))
+	}
+
+	s.closeLast()
+	slv, err := solver.New(s.system, target, s.search.timeLimit(), opts...)
+	if err != nil {
+		return err
+	}
+	s.last = slv
+
+	start := time.Now()
+	if err = slv.WriteInitialAxioms(); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	s.result = slv.SolveContext(ctx)
+	duration := time.Since(start)
+
+	fmt.Print(slv.ThoughtChain())
+	fmt.Println("Result:", s.result.Status)
+	fmt.Println("Time elapsed:", duration)
+	return nil
+}
+
+// show печатает последнее доказательство в одном из форматов prove -format, по умолчанию text.
+func (s *session) show(arg string) error {
+	if s.last == nil {
+		return fmt.Errorf("nothing has been proved yet")
+	}
+
+	switch {
+	case arg == "" || arg == "text":
+		fmt.Print(s.last.ThoughtChain())
+		fmt.Println("Result:", s.result.Status)
+		return nil
+	case arg == "json":
+		return writeJSON(os.Stdout, s.last.Proof())
+	case !slices.Contains(formats, arg):
+		return fmt.Errorf("unknown format %q", arg)
+	case s.result.Status != solver.Proved:
+		return fmt.Errorf("the last goal has not been proved")
+	default:
+		return writeProof(os.Stdout, arg, s.result, s.printer)
+	}
+}
+
+func (s *session) addHypotheses(arg string) error {
+	if arg == "" {
+		return fmt.Errorf("usage: hyp <formula>, ...")
+	}
+
+	hypotheses, err := parseList(arg, 0, s.dialect)
+	if err != nil {
+		return fmt.Errorf("%s", describeError(err))
+	}
+	s.hypotheses = append(s.hypotheses, hypotheses...)
+	return s.listHypotheses("")
+}
+
+func (s *session) listHypotheses(string) error {
+	if len(s.hypotheses) == 0 {
+		fmt.Println("No hypotheses.")
+	}
+	for i := range s.hypotheses {
+		fmt.Printf("%d. %s\n", i+1, s.printer.Print(&s.hypotheses[i]))
+	}
+	return nil
+}
+
+func (s *session) clearHypotheses(arg string) error {
+	if arg == "" {
+		s.hypotheses = nil
+		return s.listHypotheses("")
+	}
+
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 || n > len(s.hypotheses) {
+		return fmt.Errorf("no hypothesis %s", arg)
+	}
+	s.hypotheses = append(s.hypotheses[:n-1], s.hypotheses[n:]...)
+	return s.listHypotheses("")
+}
+
+// setAxioms меняет систему аксиом. Библиотека лемм строится заново для новой системы.
+func (s *session) setAxioms(arg string) error {
+	if arg != "" {
+		var system axioms.System
+		var err error
+		if path, ok := strings.CutPrefix(arg, "file "); ok {
+			system, err = axioms.Load(strings.TrimSpace(path))
+		} else {
+			system, err = axioms.Preset(arg)
+		}
+		if err != nil {
+			return err
+		}
+
+		previous := s.system
+		s.system = system
+		if err = s.loadLibrary(); err != nil {
+			s.system = previous
+			return err
+		}
+		s.closeLast()
+		s.reportMissing()
+	}
+
+	fmt.Println("Axiom system", s.system.Name)
+	for i := range s.system.Axioms {
+		fmt.Printf("A%d. %s\n", i+1, s.printer.Print(&s.system.Axioms[i]))
+	}
+	return nil
+}
+
+func (s *session) saveLemma(string) error {
+	if s.last == nil || s.result.Status != solver.Proved {
+		return fmt.Errorf("the last goal has not been proved")
+	}
+
+	lemma, err := s.last.SaveLemma()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Saved lemma %s (%d steps)\n", s.printer.Print(&lemma.Expression), len(proof.Linearize(lemma.Proof)))
+	return nil
+}
+
+func (s *session) listLemmas(string) error {
+	for i, lemma := range s.library.Lemmas() {
+		fmt.Printf("%d. %s\n", i+1, s.printer.Print(&lemma.Expression))
+	}
+	return nil
+}
+
+func (s *session) listKnown(arg string) error {
+	if s.last == nil {
+		return fmt.Errorf("nothing has been searched yet")
+	}
+
+	limit := 20
+	if arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid limit %q", arg)
+		}
+		limit = n
+	}
+
+	known := s.last.Known()
+	fmt.Printf("%d known formulas\n", len(known))
+	for i := 0; i < len(known) && i < limit; i++ {
+		fmt.Printf("%d. %s\n", i+1, s.printer.Print(&known[i]))
+	}
+	return nil
+}
+
+// set меняет флаги поиска сессии; без аргументов печатает текущие значения.
+// При смене -library библиотека загружается заново.
+func (s *session) set(arg string) error {
+	fs := flag.NewFlagSet("set", flag.ContinueOnError)
+	fs.SetOutput(os.Stdout)
+
+	// register записывает в поля значения по умолчанию, поэтому текущие значения копируются после него
+	var next searchFlags
+	next.register(fs)
+	next = s.search
+
+	if err := fs.Parse(strings.Fields(arg)); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	if _, err := next.options(s.printer); err != nil {
+		return err
+	}
+
+	previous := s.search
+	s.search = next
+	if next.library != previous.library {
+		if err := s.loadLibrary(); err != nil {
+			s.search = previous
+			return err
+		}
+	}
+
+	if arg == "" {
+		fs.VisitAll(func(f *flag.Flag) {
+			fmt.Printf("  -%s %s\n", f.Name, f.Value.String())
+		})
+	}
+	return nil
+}
+
+func (s *session) help(string) error {
+	for _, cmd := range replCommands {
+		fmt.Printf("  %-7s %-32s %s\n", cmd.name, cmd.args, cmd.summary)
+	}
+	fmt.Printf("  %-7s %-32s %s\n", "quit", "", "leave the session")
+	return nil
+}
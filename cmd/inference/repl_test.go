@@ -0,0 +1,219 @@
+package main
+
+import (
+	"flag"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/spanwalla/logical-inference/internal/solver"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// captureStdout возвращает то, что f печатает в стандартный вывод.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+	f()
+	_ = w.Close()
+	return <-output
+}
+
+// newSession создаёт сессию с системой Мендельсона и флагами поиска по умолчанию;
+// поиск ограничен числом шагов, а не временем.
+func newSession(t *testing.T) *session {
+	t.Helper()
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &session{system: system, dialect: logicparser.Classic}
+	s.search.register(flag.NewFlagSet("test", flag.ContinueOnError))
+	s.search.timeout, s.search.steps = 0, 100000
+	if err = s.loadLibrary(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.closeLast)
+	return s
+}
+
+// execute выполняет команду сессии и возвращает её вывод.
+func execute(t *testing.T, s *session, line string) (string, error) {
+	t.Helper()
+	name, arg, _ := strings.Cut(line, " ")
+	var err error
+	output := captureStdout(t, func() { err = s.execute(name, strings.TrimSpace(arg)) })
+	return output, err
+}
+
+// mustExecute выполняет команду, которая должна завершиться без ошибки.
+func mustExecute(t *testing.T, s *session, line string) string {
+	t.Helper()
+	output, err := execute(t, s, line)
+	if err != nil {
+		t.Fatalf("%s: %v", line, err)
+	}
+	return output
+}
+
+func TestSessionHypotheses(t *testing.T) {
+	s := newSession(t)
+
+	if output := mustExecute(t, s, "hyp a, a>b"); output != "1. a\n2. a>b\n" {
+		t.Fatalf("hyp printed %q", output)
+	}
+	mustExecute(t, s, "prove b")
+	if s.result.Status != solver.Proved {
+		t.Fatalf("status = %s, want proved from the session hypotheses", s.result.Status)
+	}
+	if len(s.result.Hypotheses) != 2 {
+		t.Fatalf("proof uses %d hypotheses, want 2", len(s.result.Hypotheses))
+	}
+
+	mustExecute(t, s, "clear 1")
+	if len(s.hypotheses) != 1 || s.hypotheses[0].String() != "a>b" {
+		t.Fatalf("hypotheses after clear 1: %v", s.hypotheses)
+	}
+	mustExecute(t, s, "prove b")
+	if s.result.Status != solver.Refuted {
+		t.Fatalf("status = %s, want refuted without hypothesis a", s.result.Status)
+	}
+
+	if output := mustExecute(t, s, "clear"); output != "No hypotheses.\n" {
+		t.Fatalf("clear printed %q", output)
+	}
+	for _, line := range []string{"hyp", "hyp a>", "clear 3"} {
+		if _, err := execute(t, s, line); err == nil {
+			t.Errorf("%s: no error", line)
+		}
+	}
+}
+
+func TestSessionProve(t *testing.T) {
+	s := newSession(t)
+
+	output := mustExecute(t, s, "prove a, !a>!b |- b>a")
+	if s.result.Status != solver.Proved || !strings.Contains(output, "Result: proved\n") {
+		t.Fatalf("prove printed %q, status %s", output, s.result.Status)
+	}
+	if len(s.hypotheses) != 0 {
+		t.Fatal("premises of prove were added to the session hypotheses")
+	}
+
+	mustExecute(t, s, "prove a>b")
+	if s.result.Status != solver.Refuted {
+		t.Fatalf("status = %s, want refuted", s.result.Status)
+	}
+	for _, line := range []string{"prove", "prove a>", "unknown a"} {
+		if _, err := execute(t, s, line); err == nil {
+			t.Errorf("%s: no error", line)
+		}
+	}
+}
+
+func TestSessionLemma(t *testing.T) {
+	s := newSession(t)
+	if _, err := execute(t, s, "lemma"); err == nil {
+		t.Fatal("lemma saved without a proof")
+	}
+
+	lemmas := len(s.library.Lemmas())
+	mustExecute(t, s, "prove a>(b>b)")
+	if output := mustExecute(t, s, "lemma"); !strings.HasPrefix(output, "Saved lemma A>(B>B) (") {
+		t.Fatalf("lemma printed %q", output)
+	}
+	if len(s.library.Lemmas()) != lemmas+1 {
+		t.Fatalf("library has %d lemmas, want %d", len(s.library.Lemmas()), lemmas+1)
+	}
+	if output := mustExecute(t, s, "lemmas"); !strings.Contains(output, ". A>(B>B)\n") {
+		t.Fatalf("lemmas printed %q", output)
+	}
+
+	// Лемма из библиотеки доступна следующим поискам сессии
+	mustExecute(t, s, "prove c>(d>d)")
+	if s.result.Status != solver.Proved {
+		t.Fatalf("status = %s, want proved", s.result.Status)
+	}
+}
+
+func TestSessionKnown(t *testing.T) {
+	s := newSession(t)
+	if _, err := execute(t, s, "known"); err == nil {
+		t.Fatal("known listed formulas before a search")
+	}
+
+	for _, strategy := range []string{"", "size"} {
+		mustExecute(t, s, "set -heuristic="+strategy)
+		mustExecute(t, s, "prove (a>b)>((b>c)>(a>c))")
+
+		known := len(s.last.Known())
+		output := mustExecute(t, s, "known 3")
+		lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+		if lines[0] != strconv.Itoa(known)+" known formulas" || len(lines) != 4 {
+			t.Fatalf("heuristic %q: known 3 printed %q, want %d known formulas and 3 of them", strategy, output, known)
+		}
+
+		// Среди известных формул есть выведенные поиском, а не только начальные
+		formulas := make(map[string]bool, known)
+		for _, expr := range s.last.Known() {
+			formulas[expr.String()] = true
+		}
+		derived := false
+		for _, step := range proof.Linearize(s.result.Proof) {
+			derived = derived || step.Rule == "mp" && formulas[step.Expression.String()]
+		}
+		if !derived {
+			t.Fatalf("heuristic %q: no formula derived by modus ponens is known", strategy)
+		}
+	}
+
+	if _, err := execute(t, s, "known -1"); err == nil {
+		t.Fatal("known accepted a negative limit")
+	}
+}
+
+func TestSessionSet(t *testing.T) {
+	s := newSession(t)
+
+	mustExecute(t, s, "set -heuristic size -steps 500 -pure")
+	if s.search.strategy != "size" || s.search.steps != 500 || !s.search.pure {
+		t.Fatalf("search flags after set: %+v", s.search)
+	}
+
+	// Флаги, не указанные в set, сохраняют значения
+	mustExecute(t, s, "set -steps 1000")
+	if s.search.strategy != "size" || s.search.steps != 1000 {
+		t.Fatalf("search flags after the second set: %+v", s.search)
+	}
+
+	if output := mustExecute(t, s, "set"); !strings.Contains(output, "  -heuristic size\n") {
+		t.Fatalf("set printed %q", output)
+	}
+
+	previous := s.search
+	for _, line := range []string{"set -heuristic nope", "set -rules nope", "set -unknown", "set extra"} {
+		if _, err := execute(t, s, line); err == nil {
+			t.Errorf("%s: no error", line)
+		}
+		if s.search != previous {
+			t.Fatalf("%s changed the search flags to %+v", line, s.search)
+		}
+	}
+}
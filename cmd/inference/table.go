@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"os"
+	"strings"
+)
+
+// runTable печатает таблицу истинности формулы.
+func runTable(args []string) int {
+	fs := flag.NewFlagSet("table", flag.ContinueOnError)
+	usageFor(fs, "table [flags] formula")
+
+	var syntax syntaxFlag
+	var printing printerFlags
+	syntax.register(fs)
+	printing.register(fs)
+	if !parseFlags(fs, args) {
+		return exitError
+	}
+
+	dialect, err := syntax.dialect()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	printer, err := printing.printer()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	input := strings.Join(fs.Args(), " ")
+	if input == "" {
+		if input, err = readLine("Enter expression: "); err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading input:", err)
+			return exitError
+		}
+	}
+
+	expr, err := parseFormula(input, dialect)
+	if err != nil {
+		reportParseError(input, err)
+		return exitError
+	}
+
+	assignments, err := expr.Assignments()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	atoms := expr.Atoms()
+	header := make([]string, 0, len(atoms)+1)
+	for _, atom := range atoms {
+		header = append(header, printer.Print(expression.NewExpressionWithTerm(expression.Term{
+			Type: expression.Constant, Op: expression.Nop, Val: atom,
+		})))
+	}
+	formula := printer.Print(&expr)
+
+	widths := make([]int, len(header))
+	for i := range header {
+		widths[i] = len([]rune(header[i]))
+	}
+	fmt.Println(strings.Join(header, " ") + " | " + formula)
+
+	for assignment := range assignments {
+		row := make([]string, 0, len(atoms))
+		for i, atom := range atoms {
+			row = append(row, pad(bit(assignment[atom]), widths[i]))
+		}
+		fmt.Println(strings.Join(row, " ") + " | " + bit(expr.Evaluate(assignment)))
+	}
+	return exitProved
+}
+
+func bit(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// pad дополняет значение пробелами до ширины столбца.
+func pad(value string, width int) string {
+	if width <= len(value) {
+		return value
+	}
+	return value + strings.Repeat(" ", width-len(value))
+}
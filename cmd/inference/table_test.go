@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestRunTable(t *testing.T) {
+	var code int
+	output := captureStdout(t, func() { code = runTable([]string{"a>b"}) })
+	want := "a b | a>b\n0 0 | 1\n0 1 | 1\n1 0 | 0\n1 1 | 1\n"
+	if code != exitProved || output != want {
+		t.Fatalf("table a>b = %d %q, want %q", code, output, want)
+	}
+
+	if code = runTable([]string{"a>"}); code != exitError {
+		t.Fatalf("table of an invalid formula exited with %d, want %d", code, exitError)
+	}
+}
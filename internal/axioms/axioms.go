@@ -0,0 +1,327 @@
+package axioms
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/helper"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"github.com/tiendc/go-deepcopy"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	// bootstrapMaxLen ограничивает размер формул при автоматическом выводе начальных лемм.
+	bootstrapMaxLen = 16
+	// bootstrapRounds - число шагов попарного modus ponens при автоматическом выводе.
+	bootstrapRounds = 2
+)
+
+// bootstrapLemmas - леммы, которые ищутся в системах без готового рецепта.
+var bootstrapLemmas = []string{
+	"(!a>!b)>(b>a)",
+	"a>a",
+}
+
+// System описывает аксиоматическую систему исчисления высказываний.
+type System struct {
+	Name   string
+	Axioms []expression.Expression
+	recipe [][2]int
+}
+
+// Derivation описывает лемму, полученную по modus ponens. Индексы посылок
+// указывают в общий список: сначала аксиомы системы, затем леммы в порядке вывода.
+type Derivation struct {
+	Expression expression.Expression
+	Premises   [2]int
+	Lemma      bool // Используется ли формула как начальная лемма при поиске
+}
+
+type preset struct {
+	axioms []string
+	recipe [][2]int
+}
+
+var presets = map[string]preset{
+	"mendelson": {
+		axioms: []string{
+			"a>(b>a)",
+			"(a>(b>c))>((a>b)>(a>c))",
+			"(!a>!b)>((!a>b)>a)",
+		},
+		// Вывод леммы (!a>!b)>(b>a)
+		recipe: [][2]int{
+			{0, 0},
+			{1, 0},
+			{3, 1},
+			{4, 1},
+			{2, 5},
+			{6, 6},
+			{7, 8},
+			{3, 9},
+		},
+	},
+	"lukasiewicz": {
+		axioms: []string{
+			"(a>b)>((b>c)>(a>c))",
+			"(!a>a)>a",
+			"a>(!a>b)",
+		},
+	},
+	"frege": {
+		axioms: []string{
+			"a>(b>a)",
+			"(a>(b>c))>((a>b)>(a>c))",
+			"(a>(b>c))>(b>(a>c))",
+			"(a>b)>(!b>!a)",
+			"!!a>a",
+			"a>!!a",
+		},
+	},
+	"kleene": {
+		axioms: []string{
+			"a>(b>a)",
+			"(a>b)>((a>(b>c))>(a>c))",
+			"a>(b>(a*b))",
+			"(a*b)>a",
+			"(a*b)>b",
+			"a>(a|b)",
+			"b>(a|b)",
+			"(a>c)>((b>c)>((a|b)>c))",
+			"(a>b)>((a>!b)>!a)",
+			"!!a>a",
+		},
+	},
+	"meredith": {
+		axioms: []string{
+			"((((a>b)>(!c>!d))>c)>e)>((e>a)>(d>a))",
+		},
+	},
+}
+
+// DefaultPreset - система, используемая по умолчанию.
+const DefaultPreset = "mendelson"
+
+// Presets возвращает имена встроенных систем.
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset возвращает встроенную систему по имени.
+func Preset(name string) (System, error) {
+	p, ok := presets[strings.ToLower(name)]
+	if !ok {
+		return System{}, fmt.Errorf("unknown axiom system %q (available: %s)", name, strings.Join(Presets(), ", "))
+	}
+
+	system, err := New(strings.ToLower(name), p.axioms)
+	if err != nil {
+		return System{}, err
+	}
+	system.recipe = p.recipe
+	return system, nil
+}
+
+// New создает систему из строковых записей аксиом.
+func New(name string, formulas []string) (System, error) {
+	if len(formulas) == 0 {
+		return System{}, fmt.Errorf("axiom system %q has no axioms", name)
+	}
+
+	system := System{Name: name, Axioms: make([]expression.Expression, 0, len(formulas))}
+	for _, formula := range formulas {
+		expr, err := logicparser.Parse(formula)
+		if err != nil {
+			return System{}, fmt.Errorf("axiom %q: %w", formula, err)
+		}
+
+		expr.Standardize()
+		expr.Normalize()
+		system.Axioms = append(system.Axioms, *expr)
+	}
+	return system, nil
+}
+
+// Load читает систему из файла: по одной аксиоме в строке, строки с # считаются комментариями.
+func Load(path string) (System, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return System{}, fmt.Errorf("failed to open axiom file: %w", err)
+	}
+	defer file.Close()
+
+	formulas := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		formulas = append(formulas, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return System{}, fmt.Errorf("failed to read axiom file: %w", err)
+	}
+
+	return New(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), formulas)
+}
+
+// Bootstrap выводит начальные леммы системы. Для встроенных систем с готовым рецептом
+// сначала используется он, остальные леммы ищутся ограниченным перебором. Вторым значением
+// возвращаются леммы, которые вывести не удалось.
+func (s System) Bootstrap() ([]Derivation, []expression.Expression) {
+	goals := make([]expression.Expression, 0, len(bootstrapLemmas))
+	for _, lemma := range bootstrapLemmas {
+		expr, err := logicparser.Parse(lemma)
+		if err != nil {
+			continue
+		}
+		// Частный случай аксиомы выводить не нужно
+		if !slices.ContainsFunc(s.Axioms, func(axiom expression.Expression) bool {
+			return helper.IsInstance(axiom, *expr)
+		}) {
+			goals = append(goals, *expr)
+		}
+	}
+
+	result := s.applyRecipe()
+	for _, derivation := range result {
+		if derivation.Lemma {
+			goals = slices.DeleteFunc(goals, func(goal expression.Expression) bool {
+				return helper.IsEqual(goal, derivation.Expression)
+			})
+		}
+	}
+
+	// Индексы перебора указывают в аксиомы и его собственные формулы, сдвигаем их за рецепт
+	derivations, missing := s.saturate(goals)
+	offset := len(result)
+	for _, derivation := range derivations {
+		for k, premise := range derivation.Premises {
+			if premise >= len(s.Axioms) {
+				derivation.Premises[k] = premise + offset
+			}
+		}
+		result = append(result, derivation)
+	}
+	return result, missing
+}
+
+func (s System) applyRecipe() []Derivation {
+	if len(s.recipe) == 0 {
+		return nil
+	}
+
+	formulas := s.copyAxioms()
+	result := make([]Derivation, 0, len(s.recipe))
+
+	for _, premises := range s.recipe {
+		expr := *rules.ApplyModusPonens(formulas[premises[0]], formulas[premises[1]])
+		if expr.Empty() {
+			return nil
+		}
+
+		formulas = append(formulas, expr)
+		result = append(result, Derivation{Expression: expr, Premises: premises})
+	}
+
+	// Рецепт выводит одну лемму, промежуточные формулы поиск получит сам
+	result[len(result)-1].Lemma = true
+	return result
+}
+
+// saturate ищет цели попарным modus ponens и возвращает их выводы и цели, которые не найдены.
+func (s System) saturate(goals []expression.Expression) ([]Derivation, []expression.Expression) {
+	if len(goals) == 0 {
+		return nil, nil
+	}
+
+	formulas := s.copyAxioms()
+	premises := make([][2]int, len(formulas))
+	known := make(map[string]bool)
+	for i := range formulas {
+		known[formulas[i].String()] = true
+	}
+
+	found := make([]int, 0, len(goals))
+	isGoal := func(expr expression.Expression) bool {
+		for k := range goals {
+			if helper.IsEqual(goals[k], expr) {
+				goals = append(goals[:k], goals[k+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+
+	for round := 0; round < bootstrapRounds && len(goals) > 0; round++ {
+		size := len(formulas)
+		for i := 0; i < size && len(goals) > 0; i++ {
+			for j := 0; j < size && len(goals) > 0; j++ {
+				expr := *rules.ApplyModusPonens(formulas[i], formulas[j])
+				if expr.Empty() || expr.Size() > bootstrapMaxLen || known[expr.String()] {
+					continue
+				}
+
+				known[expr.String()] = true
+				formulas = append(formulas, expr)
+				premises = append(premises, [2]int{i, j})
+				if isGoal(expr) {
+					found = append(found, len(formulas)-1)
+				}
+			}
+		}
+	}
+
+	// Оставляем только формулы, участвующие в выводе найденных лемм
+	needed := make(map[int]bool)
+	var mark func(idx int)
+	mark = func(idx int) {
+		if idx < len(s.Axioms) || needed[idx] {
+			return
+		}
+		needed[idx] = true
+		mark(premises[idx][0])
+		mark(premises[idx][1])
+	}
+	for _, idx := range found {
+		mark(idx)
+	}
+
+	remapping := make(map[int]int)
+	for i := range s.Axioms {
+		remapping[i] = i
+	}
+
+	result := make([]Derivation, 0, len(needed))
+	for i := len(s.Axioms); i < len(formulas); i++ {
+		if !needed[i] {
+			continue
+		}
+
+		remapping[i] = len(s.Axioms) + len(result)
+		result = append(result, Derivation{
+			Expression: formulas[i],
+			Premises:   [2]int{remapping[premises[i][0]], remapping[premises[i][1]]},
+			Lemma:      slices.Contains(found, i),
+		})
+	}
+	return result, goals
+}
+
+func (s System) copyAxioms() []expression.Expression {
+	var formulas []expression.Expression
+	_ = deepcopy.Copy(&formulas, &s.Axioms)
+	return formulas
+}
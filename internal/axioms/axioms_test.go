@@ -0,0 +1,54 @@
+package axioms
+
+import (
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"slices"
+	"testing"
+)
+
+func TestBootstrap(t *testing.T) {
+	tests := []struct {
+		system  string
+		lemmas  []string
+		missing []string
+	}{
+		{"mendelson", []string{"(!A>!B)>(B>A)", "A>A"}, nil},
+		{"lukasiewicz", []string{"A>A"}, []string{"(!A>!B)>(B>A)"}},
+		// !!a>a совпадает с a>a, а (!a>!b)>(b>a) - частный случай аксиомы (a>b)>(!b>!a)
+		{"frege", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system, func(t *testing.T) {
+			system, err := Preset(tt.system)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			derivations, missing := system.Bootstrap()
+			formulas := system.copyAxioms()
+			var lemmas []string
+			for i, derivation := range derivations {
+				expr := rules.ApplyModusPonens(formulas[derivation.Premises[0]], formulas[derivation.Premises[1]])
+				if expr.String() != derivation.Expression.String() {
+					t.Fatalf("derivation %d: modus ponens gives %s, want %s", i, expr.String(), derivation.Expression.String())
+				}
+				formulas = append(formulas, derivation.Expression)
+				if derivation.Lemma {
+					lemmas = append(lemmas, derivation.Expression.String())
+				}
+			}
+
+			var missed []string
+			for i := range missing {
+				missed = append(missed, missing[i].String())
+			}
+			if !slices.Equal(lemmas, tt.lemmas) {
+				t.Errorf("lemmas = %v, want %v", lemmas, tt.lemmas)
+			}
+			if !slices.Equal(missed, tt.missing) {
+				t.Errorf("missing = %v, want %v", missed, tt.missing)
+			}
+		})
+	}
+}
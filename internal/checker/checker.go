@@ -0,0 +1,206 @@
+package checker
+
+import (
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/helper"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"github.com/tiendc/go-deepcopy"
+	"maps"
+	"slices"
+)
+
+// StepError описывает шаг, не прошедший проверку. Step - номер шага, начиная с 1.
+type StepError struct {
+	Step   int
+	Reason string
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %d: %s", e.Step, e.Reason)
+}
+
+// Checker проверяет доказательства независимо от решателя: каждый шаг должен быть частным
+// случаем схемы аксиом, гипотезой или следовать по modus ponens из указанных предыдущих шагов.
+// Шаги по другим правилам проверяются правилами из реестра пакета rules.
+//
+// Шаг с переменными - схема: он верен при любой подстановке, поэтому шаг, полученный
+// из схем, должен быть частным случаем наиболее общего заключения правила. Гипотезы -
+// формулы без переменных: гипотеза-схема позволила бы вывести любой её частный случай,
+// поэтому Check отвергает такие гипотезы.
+//
+// Шаг hyp с формулой, которой нет среди гипотез, - допущение. Шаг deduction A>B из шага B
+// снимает допущение A по теореме о дедукции; последний шаг не должен зависеть от
+// неснятых допущений. Шаги deduction принимаются, только если A>(B>A)
+// и (A>(B>C))>((A>B)>(A>C)) - аксиомы системы: тогда proof.Deduction заменяет их шагами modus ponens.
+type Checker struct {
+	axioms     []expression.Expression
+	hypotheses map[string]bool
+	schemas    []expression.Expression // Гипотезы с переменными
+	deduction  error                   // Почему шаги deduction недопустимы в системе
+}
+
+// New создаёт проверку для системы аксиом и гипотез. Гипотезы, как и цель в Check,
+// сравниваются в базисе импликации и отрицания.
+func New(axioms []expression.Expression, hypotheses ...expression.Expression) *Checker {
+	c := &Checker{hypotheses: make(map[string]bool, len(hypotheses))}
+	_ = deepcopy.Copy(&c.axioms, &axioms)
+	c.deduction = proof.RequireDeductionAxioms(c.axioms)
+	for i := range hypotheses {
+		if len(hypotheses[i].Variables()) != 0 {
+			c.schemas = append(c.schemas, hypotheses[i])
+			continue
+		}
+		hypothesis := standardized(hypotheses[i])
+		c.hypotheses[hypothesis.String()] = true
+	}
+	return c
+}
+
+// Check проверяет шаги доказательства и то, что цель - частный случай последнего шага.
+func (c *Checker) Check(steps []proof.Step, target expression.Expression) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("empty proof")
+	}
+	if len(c.schemas) != 0 {
+		return fmt.Errorf("hypothesis %s contains variables", c.schemas[0].String())
+	}
+
+	// Допущения, от которых зависит каждый шаг
+	open := make([]map[string]bool, len(steps))
+	for i := range steps {
+		if reason := c.checkStep(steps, i); reason != "" {
+			return &StepError{Step: i + 1, Reason: reason}
+		}
+		open[i] = c.assumptions(steps, i, open)
+	}
+
+	last := steps[len(steps)-1].Expression
+	if undischarged := slices.Sorted(maps.Keys(open[len(steps)-1])); len(undischarged) != 0 {
+		return fmt.Errorf("%s depends on the undischarged assumption %s", last.String(), undischarged[0])
+	}
+	if goal := standardized(target); !helper.IsInstance(last, goal) {
+		return fmt.Errorf("%s is not an instance of the last step %s", target.String(), last.String())
+	}
+	return nil
+}
+
+// assumptions возвращает допущения, от которых зависит шаг idx; open - допущения предыдущих шагов.
+func (c *Checker) assumptions(steps []proof.Step, idx int, open []map[string]bool) map[string]bool {
+	step := steps[idx]
+	result := make(map[string]bool)
+	for _, premise := range step.Premises {
+		for assumption := range open[premise] {
+			result[assumption] = true
+		}
+	}
+
+	switch step.Rule {
+	case "hyp":
+		if !c.hypotheses[step.Expression.String()] {
+			result[step.Expression.String()] = true
+		}
+	case "deduction":
+		delete(result, step.Expression.CopySubtree(step.Expression.Subtree(0).Left()).String())
+	}
+	return result
+}
+
+// checkStep возвращает причину, по которой шаг неверен, или пустую строку.
+func (c *Checker) checkStep(steps []proof.Step, idx int) string {
+	step := steps[idx]
+	if step.Expression.Empty() {
+		return "empty formula"
+	}
+
+	for _, premise := range step.Premises {
+		if premise < 0 || premise >= idx {
+			return fmt.Sprintf("premise %d does not precede the step", premise+1)
+		}
+	}
+
+	switch step.Rule {
+	case "axiom":
+		if len(step.Premises) != 0 {
+			return "axiom takes no premises"
+		}
+		for i := range c.axioms {
+			if helper.IsInstance(c.axioms[i], step.Expression) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%s is not an instance of any axiom", step.Expression.String())
+	case "hyp":
+		if len(step.Premises) != 0 {
+			return "hypothesis takes no premises"
+		}
+		// Допущение с переменными можно было бы конкретизировать по-разному в разных шагах
+		if !c.hypotheses[step.Expression.String()] && len(step.Expression.Variables()) != 0 {
+			return fmt.Sprintf("assumption %s contains variables", step.Expression.String())
+		}
+		return ""
+	case "deduction":
+		if c.deduction != nil {
+			return fmt.Sprintf("deduction theorem is not justified by the axioms: %v", c.deduction)
+		}
+		if len(step.Premises) != 1 {
+			return "deduction takes one premise"
+		}
+		if step.Expression.Nodes[0].Term.Op != expression.Implication {
+			return fmt.Sprintf("%s is not an implication", step.Expression.String())
+		}
+		premise := steps[step.Premises[0]].Expression
+		consequent := *step.Expression.CopySubtree(step.Expression.Subtree(0).Right())
+		if !helper.IsInstance(premise, consequent) {
+			return fmt.Sprintf("%s is not an instance of %s", consequent.String(), premise.String())
+		}
+		return ""
+	case "mp":
+		if len(step.Premises) != 2 {
+			return "modus ponens takes two premises"
+		}
+		minor, major := steps[step.Premises[0]].Expression, steps[step.Premises[1]].Expression
+		conclusion := *rules.ApplyModusPonens(minor, major)
+		if conclusion.Empty() {
+			return fmt.Sprintf("modus ponens is not applicable to %s and %s", minor.String(), major.String())
+		}
+		if !helper.IsInstance(conclusion, step.Expression) {
+			return fmt.Sprintf("%s does not follow from %s and %s", step.Expression.String(), minor.String(), major.String())
+		}
+		return ""
+	default:
+		return c.checkRule(steps, step)
+	}
+}
+
+// checkRule проверяет шаг по правилу из реестра: шаг должен быть частным случаем
+// одного из заключений правила из указанных посылок.
+func (c *Checker) checkRule(steps []proof.Step, step proof.Step) string {
+	rule, err := rules.ByName(step.Rule)
+	if err != nil {
+		return err.Error()
+	}
+	if len(step.Premises) != rule.Arity() {
+		return fmt.Sprintf("rule %s takes %d premises", rule.Name(), rule.Arity())
+	}
+
+	premises := make([]expression.Expression, 0, len(step.Premises))
+	for _, premise := range step.Premises {
+		premises = append(premises, steps[premise].Expression)
+	}
+	for _, conclusion := range rule.Apply(premises...) {
+		if helper.IsInstance(conclusion, step.Expression) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s does not follow by rule %s", step.Expression.String(), rule.Name())
+}
+
+// standardized возвращает копию формулы в базисе импликации и отрицания.
+func standardized(expr expression.Expression) expression.Expression {
+	var result expression.Expression
+	_ = deepcopy.Copy(&result, &expr)
+	result.Standardize()
+	return result
+}
@@ -0,0 +1,143 @@
+package checker
+
+import (
+	"errors"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"strings"
+	"testing"
+)
+
+// identity - вывод a>a в системе Мендельсона.
+const identity = `target a>a
+(a>((a>a)>a))>((a>(a>a))>(a>a)) axiom
+a>((a>a)>a) axiom
+(a>(a>a))>(a>a) mp 2 1
+a>(a>a) axiom
+a>a mp 4 3
+`
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		step     int // Номер отвергнутого шага; 0 - доказательство верно, -1 - ошибка не в шаге
+	}{
+		{"pure proof", identity, 0},
+		{"schema proof", "target a>a\n(A>((A>A)>A))>((A>(A>A))>(A>A)) axiom\nA>((A>A)>A) axiom\n" +
+			"(A>(A>A))>(A>A) mp 2 1\nA>(A>A) axiom\nA>A mp 4 3\n", 0},
+		{"hypotheses", "hyp a\nhyp a>b\ntarget b\na hyp\na>b hyp\nb mp 1 2\n", 0},
+		{"rule from registry", "hyp a>b\nhyp b>c\ntarget a>c\na>b hyp\nb>c hyp\na>c hs 1 2\n", 0},
+		{"rule with wrong conclusion", "hyp a>b\nhyp b>c\ntarget c>a\na>b hyp\nb>c hyp\nc>a hs 1 2\n", 3},
+		{"not an axiom", "target a>(b>b)\na>(b>b) axiom\n", 1},
+		{"axiom with premises", "target a>(b>a)\na>(b>a) axiom\na>(b>a) axiom 1\n", 2},
+		{"undischarged assumption", "hyp a\ntarget b\nb hyp\n", -1},
+		{"wrong conclusion", "hyp a\nhyp a>b\ntarget c\na hyp\na>b hyp\nc mp 1 2\n", 3},
+		{"premises swapped", "hyp a\nhyp a>b\ntarget b\na hyp\na>b hyp\nb mp 2 1\n", 3},
+		{"mp not applicable", "hyp a\nhyp b>c\ntarget c\na hyp\nb>c hyp\nc mp 1 2\n", 3},
+		{"premise does not precede", "hyp a\nhyp a>b\ntarget b\na hyp\nb mp 1 3\na>b hyp\n", 2},
+		{"unknown rule", "hyp a\ntarget a\na guess 1\n", 1},
+		{"target in original connectives", strings.Replace(identity, "target a>a", "target !a|a", 1), 0},
+		{"hypothesis in original connectives", "hyp a|b\nhyp !a\ntarget b\n!a hyp\n!a>b hyp\nb mp 1 2\n", 0},
+		{"deduction", "target a>a\na hyp\na>a deduction 1\n", 0},
+		{"nested deduction", "target a>(b>a)\na hyp\nb>a deduction 1\na>(b>a) deduction 2\n", 0},
+		{"deduction from a schema", "target b>(c>c)\n(A>((A>A)>A))>((A>(A>A))>(A>A)) axiom\nA>((A>A)>A) axiom\n" +
+			"(A>(A>A))>(A>A) mp 2 1\nA>(A>A) axiom\nA>A mp 4 3\nb>(c>c) deduction 5\n", 0},
+		{"deduction with wrong consequent", "target a>b\na hyp\na>b deduction 1\n", 2},
+		{"deduction without premise", "target a>a\na hyp\na>a deduction\n", 2},
+		{"discharged wrong assumption", "target b>a\na hyp\nb>a deduction 1\n", -1},
+		{"schema assumption", "target A>A\nA hyp\nA>A deduction 1\n", 1},
+		{"wrong target", strings.Replace(identity, "target a>a", "target b>b", 1), -1},
+		{"schema hypothesis", "hyp A\ntarget b\nA hyp\nb mp 1 1\n", -1},
+	}
+
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := proof.ReadDocument(strings.NewReader(tt.document))
+			if err != nil {
+				t.Fatalf("ReadDocument: %v", err)
+			}
+
+			err = New(system.Axioms, doc.Hypotheses...).Check(doc.Steps, doc.Target)
+			var stepErr *StepError
+			switch {
+			case tt.step == 0 && err != nil:
+				t.Fatalf("Check: %v", err)
+			case tt.step == 0:
+			case err == nil:
+				t.Fatal("Check accepted an invalid proof")
+			case tt.step < 0 && errors.As(err, &stepErr):
+				t.Fatalf("Check: %v, want an error outside the steps", err)
+			case tt.step > 0 && (!errors.As(err, &stepErr) || stepErr.Step != tt.step):
+				t.Fatalf("Check: %v, want an error in step %d", err, tt.step)
+			}
+		})
+	}
+}
+
+func TestCheckDeductionRequiresSchemas(t *testing.T) {
+	weak, err := axioms.New("weak", []string{"a>a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lukasiewicz, err := axioms.Preset("lukasiewicz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := proof.ReadDocument(strings.NewReader("target a>(b>a)\na hyp\nb>a deduction 1\na>(b>a) deduction 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, system := range []axioms.System{weak, lukasiewicz} {
+		err = New(system.Axioms, doc.Hypotheses...).Check(doc.Steps, doc.Target)
+		var stepErr *StepError
+		if !errors.As(err, &stepErr) || stepErr.Step != 2 {
+			t.Errorf("%s: Check: %v, want an error in step 2", system.Name, err)
+		}
+	}
+}
+
+func TestCheckRegisteredRule(t *testing.T) {
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weakening, err := rules.NewDerived("checker-weaken", 1, "a>(b>a)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accepted, err := proof.ReadDocument(strings.NewReader("hyp a\ntarget b>a\na hyp\nb>a checker-weaken 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejected, err := proof.ReadDocument(strings.NewReader("hyp a\ntarget a>b\na hyp\na>b checker-weaken 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Реестр общий для всех тестов пакета, правило могло быть зарегистрировано предыдущим запуском
+	var stepErr *StepError
+	if _, err = rules.ByName(weakening.Name()); err != nil {
+		err = New(system.Axioms, accepted.Hypotheses...).Check(accepted.Steps, accepted.Target)
+		if !errors.As(err, &stepErr) || stepErr.Step != 2 {
+			t.Fatalf("Check before registration: %v, want an error in step 2", err)
+		}
+		if err = rules.Register(weakening); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = New(system.Axioms, accepted.Hypotheses...).Check(accepted.Steps, accepted.Target); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if err = New(system.Axioms, rejected.Hypotheses...).Check(rejected.Steps, rejected.Target); !errors.As(err, &stepErr) || stepErr.Step != 2 {
+		t.Fatalf("Check: %v, want an error in step 2", err)
+	}
+}
@@ -0,0 +1,191 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Assignment сопоставляет атомам выражения (переменным и константам) истинностные значения.
+type Assignment map[Value]bool
+
+// Atoms возвращает отсортированный список различных атомов выражения.
+func (e *Expression) Atoms() []Value {
+	result := make([]Value, 0, len(e.Nodes))
+	for _, node := range e.Nodes {
+		if node.Term.Type == Variable || node.Term.Type == Constant {
+			result = append(result, node.Term.Val)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// Evaluate вычисляет значение выражения на наборе. Отсутствующие в наборе атомы считаются ложными.
+func (e *Expression) Evaluate(assignment Assignment) bool {
+	if e.Empty() {
+		return false
+	}
+
+	var f func(idx uint) bool
+	f = func(idx uint) bool {
+		term := e.Nodes[idx].Term
+		if term.Type != Function {
+			return assignment[term.Val] != (term.Op == Negation)
+		}
+
+		lhs := f(e.Subtree(idx).Left())
+		rhs := f(e.Subtree(idx).Right())
+
+		switch term.Op {
+		case Implication:
+			return !lhs || rhs
+		case Disjunction:
+			return lhs || rhs
+		case Conjunction:
+			return lhs && rhs
+		case Xor:
+			return lhs != rhs
+		case Equivalent:
+			return lhs == rhs
+		default:
+			return false
+		}
+	}
+	return f(e.Subtree(0).Self())
+}
+
+// MaxAtoms - наибольшее число атомов, для которого перебираются наборы значений (2^MaxAtoms наборов).
+const MaxAtoms = 24
+
+// ErrTooManyAtoms возвращается, если в выражении больше MaxAtoms атомов.
+var ErrTooManyAtoms = errors.New("too many atoms for a truth table")
+
+// Assignments перечисляет все наборы значений атомов выражения в лексикографическом порядке.
+// Наборы строятся по мере перебора, поэтому перебор можно прервать, не перечисляя остальные.
+func (e *Expression) Assignments() (iter.Seq[Assignment], error) {
+	atoms := e.Atoms()
+	if len(atoms) > MaxAtoms {
+		return nil, fmt.Errorf("%w: %d, at most %d", ErrTooManyAtoms, len(atoms), MaxAtoms)
+	}
+
+	return func(yield func(Assignment) bool) {
+		for mask := 0; mask < 1<<len(atoms); mask++ {
+			assignment := make(Assignment, len(atoms))
+			for i, atom := range atoms {
+				assignment[atom] = mask&(1<<(len(atoms)-1-i)) != 0
+			}
+			if !yield(assignment) {
+				return
+			}
+		}
+	}, nil
+}
+
+// IsTautology проверяет, истинно ли выражение на всех наборах.
+// Если нет, возвращает опровергающий набор.
+func (e *Expression) IsTautology() (bool, Assignment, error) {
+	assignments, err := e.Assignments()
+	if err != nil {
+		return false, nil, err
+	}
+	for assignment := range assignments {
+		if !e.Evaluate(assignment) {
+			return false, assignment, nil
+		}
+	}
+	return true, nil, nil
+}
+
+// Describe выводит набор в виде "a=1, b=0", используя имена атомов выражения.
+func (e *Expression) Describe(assignment Assignment) string {
+	var builder strings.Builder
+
+	for i, atom := range e.Atoms() {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+
+		term := e.atomTerm(atom)
+		builder.WriteString(term.String())
+
+		if assignment[atom] {
+			builder.WriteString("=1")
+		} else {
+			builder.WriteString("=0")
+		}
+	}
+	return builder.String()
+}
+
+func (e *Expression) atomTerm(val Value) Term {
+	for _, node := range e.Nodes {
+		if node.Term.Type != Function && node.Term.Val == val {
+			return Term{Type: node.Term.Type, Op: Nop, Val: val}
+		}
+	}
+	return Term{Type: Variable, Op: Nop, Val: val}
+}
+
+// DNF возвращает совершенную дизъюнктивную нормальную форму выражения: дизъюнкцию конъюнкций
+// литералов по наборам, на которых выражение истинно. Для противоречия возвращается a*!a.
+func (e *Expression) DNF() (Expression, error) {
+	return e.canonical(true)
+}
+
+// CNF возвращает совершенную конъюнктивную нормальную форму выражения: конъюнкцию дизъюнкций
+// литералов по наборам, на которых выражение ложно. Для тавтологии возвращается a|!a.
+func (e *Expression) CNF() (Expression, error) {
+	return e.canonical(false)
+}
+
+// canonical строит совершенную ДНФ (dnf) или КНФ по таблице истинности.
+func (e *Expression) canonical(dnf bool) (Expression, error) {
+	inner, outer := Conjunction, Disjunction
+	if !dnf {
+		inner, outer = Disjunction, Conjunction
+	}
+
+	assignments, err := e.Assignments()
+	if err != nil {
+		return Expression{}, err
+	}
+
+	atoms := e.Atoms()
+	clauses := make([]Expression, 0)
+	for assignment := range assignments {
+		if e.Evaluate(assignment) != dnf {
+			continue
+		}
+
+		// В ДНФ литерал истинен на наборе, в КНФ - ложен
+		literals := make([]Expression, 0, len(atoms))
+		for _, atom := range atoms {
+			literal := *NewExpressionWithTerm(e.atomTerm(atom))
+			if assignment[atom] != dnf {
+				literal.Negation(0)
+			}
+			literals = append(literals, literal)
+		}
+		clauses = append(clauses, fold(literals, inner))
+	}
+
+	if len(clauses) == 0 {
+		atom := *NewExpressionWithTerm(e.atomTerm(atoms[0]))
+		negated := *NewExpressionWithTerm(e.atomTerm(atoms[0]))
+		negated.Negation(0)
+		return Construct(atom, inner, negated), nil
+	}
+	return fold(clauses, outer), nil
+}
+
+// fold соединяет выражения операцией справа налево: a op (b op c).
+func fold(exprs []Expression, op Operation) Expression {
+	result := exprs[len(exprs)-1]
+	for i := len(exprs) - 2; i >= 0; i-- {
+		result = Construct(exprs[i], op, result)
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+package expression_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"strings"
+	"testing"
+)
+
+// constant разбирает формулу с константами, как её вводит пользователь.
+func constant(t *testing.T, input string) expression.Expression {
+	t.Helper()
+	expr, err := logicparser.Parse(input)
+	if err != nil {
+		t.Fatalf("parse %q: %v", input, err)
+	}
+	expr.MakeConst()
+	return *expr
+}
+
+// assignment задаёт значения атомов формулы по их порядку в Atoms.
+func assignment(expr expression.Expression, values ...bool) expression.Assignment {
+	result := make(expression.Assignment)
+	for i, atom := range expr.Atoms() {
+		result[atom] = values[i]
+	}
+	return result
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		input  string
+		values []bool
+		want   bool
+	}{
+		{"a>b", []bool{true, false}, false},
+		{"a>b", []bool{false, false}, true},
+		{"a|b", []bool{false, true}, true},
+		{"a*b", []bool{true, false}, false},
+		{"a+b", []bool{true, true}, false},
+		{"a+b", []bool{true, false}, true},
+		{"a=b", []bool{false, false}, true},
+		{"a=b", []bool{false, true}, false},
+		{"!a", []bool{false}, true},
+		{"!(a*b)>c", []bool{true, false, false}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.input, tt.values), func(t *testing.T) {
+			expr := constant(t, tt.input)
+			if got := expr.Evaluate(assignment(expr, tt.values...)); got != tt.want {
+				t.Fatalf("Evaluate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTautology(t *testing.T) {
+	tests := []struct {
+		input          string
+		want           bool
+		counterexample string // Describe опровергающего набора
+	}{
+		{"a>a", true, ""},
+		{"a|!a", true, ""},
+		{"(a>b)>((b>c)>(a>c))", true, ""},
+		{"a>b", false, "a=1, b=0"},
+		{"a*!a", false, "a=0"},
+		{"(a=b)>(a>b)", true, ""},
+		{"(a+b)>a", false, "a=0, b=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr := constant(t, tt.input)
+			got, counterexample, err := expr.IsTautology()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("IsTautology = %v, want %v", got, tt.want)
+			}
+			if !got && expr.Describe(counterexample) != tt.counterexample {
+				t.Fatalf("counterexample %s, want %s", expr.Describe(counterexample), tt.counterexample)
+			}
+		})
+	}
+}
+
+func TestAssignments(t *testing.T) {
+	expr := constant(t, "a>(b>c)")
+	assignments, err := expr.Assignments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []string
+	for assignment := range assignments {
+		rows = append(rows, expr.Describe(assignment))
+		if len(rows) == 3 {
+			break
+		}
+	}
+	want := []string{"a=0, b=0, c=0", "a=0, b=0, c=1", "a=0, b=1, c=0"}
+	if strings.Join(rows, "; ") != strings.Join(want, "; ") {
+		t.Fatalf("assignments = %v, want %v", rows, want)
+	}
+
+	// 64 атома: перебор 2^64 наборов невозможен, и сдвиг 1<<64 переполнился бы
+	atoms := make([]string, 64)
+	for i := range atoms {
+		atoms[i] = fmt.Sprintf("a%d", i)
+	}
+	large := constant(t, strings.Join(atoms, "|"))
+	if _, err = large.Assignments(); !errors.Is(err, expression.ErrTooManyAtoms) {
+		t.Fatalf("Assignments error = %v, want %v", err, expression.ErrTooManyAtoms)
+	}
+	if _, _, err = large.IsTautology(); !errors.Is(err, expression.ErrTooManyAtoms) {
+		t.Fatalf("IsTautology error = %v, want %v", err, expression.ErrTooManyAtoms)
+	}
+	if _, err = large.DNF(); !errors.Is(err, expression.ErrTooManyAtoms) {
+		t.Fatalf("DNF error = %v, want %v", err, expression.ErrTooManyAtoms)
+	}
+}
+
+func TestCanonicalForms(t *testing.T) {
+	tests := []struct {
+		input string
+		dnf   string
+		cnf   string
+	}{
+		{"a>b", "(!a*!b)|((!a*b)|(a*b))", "!a|b"},
+		{"a+b", "(!a*b)|(a*!b)", "(a|b)*(!a|!b)"},
+		{"a|!a", "!a|a", "a|!a"},
+		{"a*!a", "a*!a", "a*!a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr := constant(t, tt.input)
+			dnf, err := expr.DNF()
+			if err != nil {
+				t.Fatal(err)
+			}
+			cnf, err := expr.CNF()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := dnf.String(); got != tt.dnf {
+				t.Errorf("DNF = %s, want %s", got, tt.dnf)
+			}
+			if got := cnf.String(); got != tt.cnf {
+				t.Errorf("CNF = %s, want %s", got, tt.cnf)
+			}
+
+			// Нормальные формы равносильны исходной формуле
+			for _, form := range []expression.Expression{dnf, cnf} {
+				equivalent := expression.Construct(expr, expression.Equivalent, form)
+				if ok, _, err := equivalent.IsTautology(); err != nil || !ok {
+					t.Errorf("%s is not equivalent to %s", form.String(), expr.String())
+				}
+			}
+		})
+	}
+}
@@ -193,34 +193,38 @@ func (e *Expression) Normalize() {
 	e.mod = true
 }
 
+// Standardize переводит выражение в базис импликации и отрицания по определениям связок:
+// a|b = !a>b, a=b = (a>b)*(b>a), a+b = !(a=b). Конъюнкция a*b в представлении выражений
+// и есть отрицание импликации !(a>!b), поэтому она сохраняется.
 func (e *Expression) Standardize() {
-	q := queue.New[uint]()
-	q.Push(0)
-
-	for q.Len() > 0 {
-		nodeIdx := *q.Pop()
-
-		if nodeIdx == invalidIdx {
-			continue
-		}
-
-		if e.Nodes[nodeIdx].Term.Type != Function {
-			continue
-		}
+	if e.Empty() {
+		return
+	}
+	*e = e.standardized(e.Subtree(0).Self())
+}
 
-		if e.Nodes[nodeIdx].Term.Op == Disjunction {
-			e.Nodes[nodeIdx].Term.Op = Implication
-			e.Negation(e.Subtree(nodeIdx).Left())
-		}
+func (e *Expression) standardized(idx uint) Expression {
+	term := e.Nodes[idx].Term
+	if term.Type != Function {
+		return *NewExpressionWithTerm(term)
+	}
 
-		if e.HasLeft(nodeIdx) {
-			q.Push(e.Subtree(nodeIdx).Left())
-		}
-		if e.HasRight(nodeIdx) {
-			q.Push(e.Subtree(nodeIdx).Right())
-		}
+	lhs := e.standardized(e.Subtree(idx).Left())
+	rhs := e.standardized(e.Subtree(idx).Right())
+
+	switch term.Op {
+	case Disjunction:
+		lhs.Negation(0)
+		return Construct(lhs, Implication, rhs)
+	case Equivalent:
+		return Construct(Construct(lhs, Implication, rhs), Conjunction, Construct(rhs, Implication, lhs))
+	case Xor:
+		result := Construct(Construct(lhs, Implication, rhs), Conjunction, Construct(rhs, Implication, lhs))
+		result.Negation(0)
+		return result
+	default:
+		return Construct(lhs, term.Op, rhs)
 	}
-	e.mod = true
 }
 
 func (e *Expression) MakeConst() {
@@ -275,6 +279,9 @@ func (e *Expression) CopySubtree(idx uint) *Expression {
 	return NewExpressionWithNodes(nodes)
 }
 
+// Contains проверяет, входит ли в выражение атом term. Сравниваются и тип, и значение:
+// переменные и константы нумеруются независимо (у константы a и переменной A значение 1),
+// и проверка вхождения при унификации не должна отвергать подстановку A → a>b.
 func (e *Expression) Contains(term Term) bool {
 	if term.Type != Variable && term.Type != Constant {
 		return false
@@ -285,7 +292,7 @@ func (e *Expression) Contains(term Term) bool {
 			continue
 		}
 
-		if node.Term.Val == term.Val {
+		if node.Term.Type == term.Type && node.Term.Val == term.Val {
 			return true
 		}
 	}
@@ -330,11 +337,13 @@ func (e *Expression) Negation(idx uint) {
 			continue
 		}
 
-		e.Nodes[nodeIdx].Term.Op = e.Nodes[nodeIdx].Term.Op.Opposite()
+		// !(a>b) = a*!b, !(a*b) = a>!b, !(a|b) = !a*!b, !(a+b) = a=b
+		op := e.Nodes[nodeIdx].Term.Op
+		e.Nodes[nodeIdx].Term.Op = op.Opposite()
 
-		if e.Nodes[nodeIdx].Term.Op == Implication || e.Nodes[nodeIdx].Term.Op == Conjunction {
+		if op == Implication || op == Conjunction {
 			q.Push(e.Subtree(nodeIdx).Right())
-		} else if e.Nodes[nodeIdx].Term.Op == Disjunction {
+		} else if op == Disjunction {
 			q.Push(e.Subtree(nodeIdx).Left())
 			q.Push(e.Subtree(nodeIdx).Right())
 		}
@@ -0,0 +1,59 @@
+package expression
+
+import "testing"
+
+func TestContains(t *testing.T) {
+	variable := func(val Value) Term { return Term{Type: Variable, Op: Nop, Val: val} }
+	constant := func(val Value) Term { return Term{Type: Constant, Op: Nop, Val: val} }
+
+	// a>B: константа a и переменная B
+	expr := Construct(*NewExpressionWithTerm(constant(1)), Implication, *NewExpressionWithTerm(variable(2)))
+
+	tests := []struct {
+		term Term
+		want bool
+	}{
+		{constant(1), true},
+		{variable(1), false},
+		{variable(2), true},
+		{constant(2), false},
+		{Term{Type: Function, Op: Implication}, false},
+	}
+	for _, tt := range tests {
+		if got := expr.Contains(tt.term); got != tt.want {
+			t.Errorf("%s contains %+v = %v, want %v", expr.String(), tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestStandardize(t *testing.T) {
+	leaf := func(val Value) Expression { return *NewExpressionWithTerm(Term{Type: Variable, Op: Nop, Val: val}) }
+	negated := func(expr Expression) Expression {
+		expr.Negation(0)
+		return expr
+	}
+	a, b, c := leaf(1), leaf(2), leaf(3)
+
+	tests := []struct {
+		expr Expression
+		want string
+	}{
+		{Construct(a, Implication, b), "A>B"},
+		{Construct(a, Conjunction, b), "A*B"},
+		{Construct(a, Disjunction, b), "!A>B"},
+		// A=B - (A>B)*(B>A)
+		{Construct(a, Equivalent, b), "(A>B)*(B>A)"},
+		// A+B (в диалекте ascii A^B) - отрицание эквивалентности: !((A>B)*(B>A)) = (A>B)>!(B>A)
+		{Construct(a, Xor, b), "(A>B)>(B*!A)"},
+		{negated(Construct(a, Xor, b)), "(A>B)*(B>A)"},
+		{Construct(Construct(a, Disjunction, b), Equivalent, c), "((!A>B)>C)*(C>(!A>B))"},
+		{Construct(a, Xor, Construct(b, Equivalent, c)), "(A>((B>C)*(C>B)))>(((B>C)*(C>B))*!A)"},
+	}
+	for _, tt := range tests {
+		original := tt.expr.String()
+		tt.expr.Standardize()
+		if got := tt.expr.String(); got != tt.want {
+			t.Errorf("Standardize(%s) = %s, want %s", original, got, tt.want)
+		}
+	}
+}
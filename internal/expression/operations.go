@@ -49,3 +49,22 @@ func (o Operation) Opposite() Operation {
 func (o Operation) IsCommutative() bool {
 	return o != Nop && o != Negation && o != Implication
 }
+
+// Priority возвращает приоритет операции при разборе и печати: чем больше, тем сильнее связывает.
+// Бинарные операции одного приоритета правоассоциативны.
+func (o Operation) Priority() int {
+	switch o {
+	case Negation:
+		return 5
+	case Conjunction:
+		return 4
+	case Disjunction:
+		return 3
+	case Xor, Equivalent:
+		return 2
+	case Implication:
+		return 1
+	default:
+		return 0
+	}
+}
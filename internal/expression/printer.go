@@ -0,0 +1,143 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Notation - обозначения операций при печати.
+type Notation int
+
+const (
+	ClassicNotation Notation = iota // ! * | > + =
+	UnicodeNotation                 // ¬ ∧ ∨ → ⊕ ↔
+	LaTeXNotation                   // \neg \land \lor \to \oplus \leftrightarrow
+)
+
+var notationNames = map[Notation]string{
+	ClassicNotation: "classic",
+	UnicodeNotation: "unicode",
+	LaTeXNotation:   "latex",
+}
+
+var notationSymbols = map[Notation]map[Operation]string{
+	UnicodeNotation: {
+		Negation:    "¬",
+		Implication: " → ",
+		Disjunction: " ∨ ",
+		Conjunction: " ∧ ",
+		Xor:         " ⊕ ",
+		Equivalent:  " ↔ ",
+	},
+	LaTeXNotation: {
+		Negation:    `\neg `,
+		Implication: ` \to `,
+		Disjunction: ` \lor `,
+		Conjunction: ` \land `,
+		Xor:         ` \oplus `,
+		Equivalent:  ` \leftrightarrow `,
+	},
+}
+
+func (n Notation) String() string {
+	return notationNames[n]
+}
+
+// Notations возвращает имена обозначений.
+func Notations() []string {
+	return []string{ClassicNotation.String(), UnicodeNotation.String(), LaTeXNotation.String()}
+}
+
+// NotationByName возвращает обозначения по имени.
+func NotationByName(name string) (Notation, error) {
+	for notation, notationName := range notationNames {
+		if notationName == name {
+			return notation, nil
+		}
+	}
+	return ClassicNotation, fmt.Errorf("unknown notation %q, available: %s", name, strings.Join(Notations(), ", "))
+}
+
+// Printer печатает выражения для чтения человеком. Нулевое значение печатает так же, как
+// String: классические обозначения и скобки вокруг каждой операции, кроме корневой.
+// String остаётся каноническим представлением, по которому сравниваются выражения.
+type Printer struct {
+	Notation Notation
+	// MinimalParentheses оставляет только скобки, необходимые с учётом приоритетов
+	// и правой ассоциативности операций.
+	MinimalParentheses bool
+}
+
+// Print печатает выражение.
+func (p Printer) Print(e *Expression) string {
+	if p == (Printer{}) || e.Empty() {
+		return e.String()
+	}
+
+	var builder strings.Builder
+	var f func(idx uint, parent Operation, isLeft bool)
+	f = func(idx uint, parent Operation, isLeft bool) {
+		term := e.Nodes[idx].Term
+		if term.Type != Function {
+			builder.WriteString(p.atom(term))
+			return
+		}
+
+		brackets := parent != Nop
+		if p.MinimalParentheses && parent != Nop {
+			brackets = term.Op.Priority() < parent.Priority() ||
+				(term.Op.Priority() == parent.Priority() && isLeft)
+		}
+		if brackets {
+			builder.WriteString("(")
+		}
+
+		f(e.Subtree(idx).Left(), term.Op, true)
+		builder.WriteString(p.operation(term.Op))
+		f(e.Subtree(idx).Right(), term.Op, false)
+
+		if brackets {
+			builder.WriteString(")")
+		}
+	}
+	f(e.Subtree(0).Self(), Nop, false)
+	return builder.String()
+}
+
+func (p Printer) operation(op Operation) string {
+	if symbols, ok := notationSymbols[p.Notation]; ok {
+		return symbols[op]
+	}
+	return op.String()
+}
+
+// atom печатает переменную или константу с возможным отрицанием.
+func (p Printer) atom(term Term) string {
+	var name string
+	if term.Type == Constant {
+		name = Symbols.Name(term.Val)
+	} else {
+		name = generatedName(term.Val, true)
+	}
+
+	if p.Notation == LaTeXNotation {
+		name = latexName(name)
+	}
+	if term.Op == Negation {
+		return p.operation(Negation) + name
+	}
+	return name
+}
+
+// latexName оформляет имя для формулы LaTeX: A1 - A_{1}, длинные имена - \mathit{...}.
+func latexName(name string) string {
+	letters := strings.TrimRightFunc(name, unicode.IsDigit)
+	if len(letters) == 1 && len(name) > 1 {
+		return letters + "_{" + name[1:] + "}"
+	}
+	if len(name) > 1 {
+		return `\mathit{` + strings.ReplaceAll(name, "_", `\_`) + "}"
+	}
+	return name
+}
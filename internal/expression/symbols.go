@@ -0,0 +1,140 @@
+package expression
+
+import (
+	"strconv"
+	"sync"
+)
+
+// lettersCount - число однобуквенных имён. Имена a–z всегда имеют значения 1–26.
+const lettersCount = 26
+
+// SymbolTable сопоставляет значениям атомов их имена. Однобуквенные имена соответствуют
+// номеру буквы в алфавите, остальные получают значения начиная с 27 в порядке появления.
+type SymbolTable struct {
+	mu     sync.RWMutex
+	names  map[Value]string
+	values map[string]Value
+	next   Value
+}
+
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{
+		names:  make(map[Value]string),
+		values: make(map[string]Value),
+		next:   lettersCount + 1,
+	}
+}
+
+// Symbols - таблица имён, общая для разбора и печати выражений.
+var Symbols = NewSymbolTable()
+
+// Intern возвращает значение имени, добавляя его в таблицу при необходимости. Имя вида
+// буква+номер получает значение, с которым печатается значение без имени, если оно свободно,
+// поэтому напечатанная формула читается с теми же значениями.
+func (t *SymbolTable) Intern(name string) Value {
+	if len(name) == 1 && 'a' <= name[0] && name[0] <= 'z' {
+		return Value(name[0]-'a') + 1
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if val, ok := t.values[name]; ok {
+		return val
+	}
+	if val, ok := generatedValue(name, false); ok {
+		if _, taken := t.names[val]; !taken {
+			t.add(val, name)
+			return val
+		}
+	}
+	for {
+		if _, taken := t.names[t.next]; !taken {
+			break
+		}
+		t.next++
+	}
+	t.add(t.next, name)
+	return t.next
+}
+
+// Lookup возвращает значение имени, если оно есть в таблице.
+func (t *SymbolTable) Lookup(name string) (Value, bool) {
+	if len(name) == 1 && 'a' <= name[0] && name[0] <= 'z' {
+		return Value(name[0]-'a') + 1, true
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	val, ok := t.values[name]
+	return val, ok
+}
+
+// Name возвращает имя значения. Значение без имени печатается именем вида буква+номер,
+// например b1 для 28: Intern выдаёт такое имя только этому значению, а таблица при
+// печати не меняется.
+func (t *SymbolTable) Name(val Value) string {
+	if 1 <= val && val <= lettersCount {
+		return string(rune('a' + val - 1))
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if name, ok := t.names[val]; ok {
+		return name
+	}
+	return generatedName(val, false)
+}
+
+func (t *SymbolTable) add(val Value, name string) {
+	t.names[val] = name
+	t.values[name] = val
+}
+
+// generatedName строит имя вида буква+номер: 1–26 - буквы, 27 - a1 (A1), 28 - b1 (B1) и т.д.
+func generatedName(val Value, uppercase bool) string {
+	if val < 1 {
+		val = -val + 1
+	}
+
+	first := 'a'
+	if uppercase {
+		first = 'A'
+	}
+
+	name := string(first + rune((val-1)%lettersCount))
+	if number := (val - 1) / lettersCount; number > 0 {
+		name += strconv.Itoa(int(number))
+	}
+	return name
+}
+
+// GeneratedValue возвращает значение по имени переменной схемы вида A, B, …, A1 - обратное к
+// печати переменных.
+func GeneratedValue(name string) (Value, bool) {
+	return generatedValue(name, true)
+}
+
+// generatedValue - обратное к generatedName.
+func generatedValue(name string, uppercase bool) (Value, bool) {
+	first := byte('a')
+	if uppercase {
+		first = 'A'
+	}
+	if name == "" || name[0] < first || name[0] >= first+lettersCount {
+		return 0, false
+	}
+
+	number := 0
+	if len(name) > 1 {
+		if name[1] == '0' {
+			return 0, false
+		}
+		n, err := strconv.Atoi(name[1:])
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		number = n
+	}
+	return Value(number*lettersCount+int(name[0]-first)) + 1, true
+}
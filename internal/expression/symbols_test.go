@@ -0,0 +1,36 @@
+package expression
+
+import "testing"
+
+func TestSymbolTable(t *testing.T) {
+	table := NewSymbolTable()
+
+	// Значение без имени печатается сгенерированным именем и не попадает в таблицу
+	if name := table.Name(28); name != "b1" {
+		t.Fatalf("Name(28) = %q, want b1", name)
+	}
+	if _, ok := table.Lookup("b1"); ok {
+		t.Fatal("Name added b1 to the table")
+	}
+
+	steps := []struct {
+		name string
+		want Value
+	}{
+		{"q", 17},
+		{"b1", 28},  // Сгенерированное имя получает своё значение
+		{"foo", 27}, // Первое свободное значение
+		{"a1", 29},  // Значение 27 уже занято foo
+		{"bar", 30}, // Следующее свободное значение
+		{"foo", 27}, // Повторное имя
+		{"c2", 2*26 + 3},
+	}
+	for _, step := range steps {
+		if got := table.Intern(step.name); got != step.want {
+			t.Fatalf("Intern(%q) = %d, want %d", step.name, got, step.want)
+		}
+		if got := table.Name(step.want); got != step.name {
+			t.Fatalf("Name(%d) = %q, want %q", step.want, got, step.name)
+		}
+	}
+}
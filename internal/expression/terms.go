@@ -1,10 +1,6 @@
 package expression
 
-import (
-	"github.com/spanwalla/logical-inference/internal/pkg/alphabet"
-	"math"
-	"strings"
-)
+import "strings"
 
 type Value int
 type TermType int
@@ -34,12 +30,12 @@ func (t Term) String() string {
 			builder.WriteString(t.Op.String())
 		}
 
-		isConst := t.Type == Constant
-		letter, err := alphabet.GetLetter(int(math.Abs(float64(t.Val))), !isConst)
-		if err != nil {
-			return "Err"
+		// Константы печатаются именами из таблицы, переменные схем - заглавными буквами с номером
+		if t.Type == Constant {
+			builder.WriteString(Symbols.Name(t.Val))
+		} else {
+			builder.WriteString(generatedName(t.Val, true))
 		}
-		builder.WriteRune(letter)
 	}
 	return builder.String()
 }
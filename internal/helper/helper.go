@@ -74,7 +74,19 @@ func IsEqual(left, right expression.Expression) bool {
 	return leftCopy.Equals(rightCopy, true)
 }
 
+// GetUnification унифицирует left и right, предварительно сдвигая переменные right выше
+// переменных left. Ключи подстановки для right - сдвинутые значения.
 func GetUnification(left, right expression.Expression, substitution *map[expression.Value]expression.Expression) bool {
+	var rightCopy expression.Expression
+	_ = deepcopy.Copy(&rightCopy, &right)
+	rightCopy.ChangeVariables(left.MaxValue() + 1)
+	return Unify(left, rightCopy, 0, substitution)
+}
+
+// Unify ищет наиболее общий унификатор left и right без переименования переменных:
+// одинаковые значения в left и right - одна и та же переменная. Новые переменные
+// получают значения не меньше fresh и больше всех переменных left и right.
+func Unify(left, right expression.Expression, fresh expression.Value, substitution *map[expression.Value]expression.Expression) bool {
 	sub := make(map[expression.Value]expression.Expression)
 	subContains := func(key expression.Value) bool {
 		_, ok := sub[key]
@@ -84,8 +96,7 @@ func GetUnification(left, right expression.Expression, substitution *map[express
 	var leftCopy, rightCopy expression.Expression
 	_ = deepcopy.Copy(&leftCopy, &left)
 	_ = deepcopy.Copy(&rightCopy, &right)
-	rightCopy.ChangeVariables(leftCopy.MaxValue() + 1)
-	v := rightCopy.MaxValue() + 1
+	v := max(fresh, leftCopy.MaxValue()+1, rightCopy.MaxValue()+1)
 
 	q := queue.New[[2]uint]()
 	q.Push([2]uint{leftCopy.Subtree(0).Self(), rightCopy.Subtree(0).Self()})
@@ -299,3 +310,59 @@ func GetUnification(left, right expression.Expression, substitution *map[express
 	*substitution = sub
 	return true
 }
+
+// Match ищет подстановку переменных general, переводящую его в specific (одностороннее сопоставление).
+// Переменные specific при этом считаются константами и не заменяются.
+func Match(general, specific expression.Expression, substitution *map[expression.Value]expression.Expression) bool {
+	if general.Empty() || specific.Empty() {
+		return false
+	}
+
+	sub := make(map[expression.Value]expression.Expression)
+	bound := make(map[expression.Value]string)
+
+	var match func(gi, si uint) bool
+	match = func(gi, si uint) bool {
+		gTerm, sTerm := general.Nodes[gi].Term, specific.Nodes[si].Term
+
+		switch gTerm.Type {
+		case expression.Variable:
+			value := *specific.CopySubtree(si)
+			if gTerm.Op == expression.Negation {
+				value.Negation(0)
+			}
+
+			if rep, ok := bound[gTerm.Val]; ok {
+				return rep == value.String()
+			}
+			bound[gTerm.Val] = value.String()
+			sub[gTerm.Val] = value
+			return true
+		case expression.Function:
+			if sTerm.Type != expression.Function || sTerm.Op != gTerm.Op {
+				return false
+			}
+			return match(general.Subtree(gi).Left(), specific.Subtree(si).Left()) &&
+				match(general.Subtree(gi).Right(), specific.Subtree(si).Right())
+		default:
+			return gTerm == sTerm
+		}
+	}
+
+	if !match(general.Subtree(0).Self(), specific.Subtree(0).Self()) {
+		return false
+	}
+
+	if substitution != nil {
+		*substitution = sub
+	}
+	return true
+}
+
+// IsInstance проверяет, является ли specific частным случаем general.
+func IsInstance(general, specific expression.Expression) bool {
+	if general.Size() > specific.Size() {
+		return false
+	}
+	return Match(general, specific, nil)
+}
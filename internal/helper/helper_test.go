@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"testing"
+)
+
+func schema(t *testing.T, input string) expression.Expression {
+	t.Helper()
+	expr, err := logicparser.ParseSchema(input)
+	if err != nil {
+		t.Fatalf("parse %q: %v", input, err)
+	}
+	return *expr
+}
+
+func TestGetUnificationOccursCheck(t *testing.T) {
+	tests := []struct {
+		left, right string
+		ok          bool
+	}{
+		// Константа a имеет то же значение, что и переменная A, но это разные атомы
+		{"A", "a>b", true},
+		{"A>a", "(a>b)>a", true},
+		{"A>A", "B>(B>a)", false},
+		{"A", "A>b", true},
+		{"a", "b", false},
+	}
+
+	for _, tt := range tests {
+		sub := make(map[expression.Value]expression.Expression)
+		if got := GetUnification(schema(t, tt.left), schema(t, tt.right), &sub); got != tt.ok {
+			t.Errorf("GetUnification(%s, %s) = %v, want %v", tt.left, tt.right, got, tt.ok)
+		}
+	}
+}
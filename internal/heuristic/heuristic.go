@@ -0,0 +1,143 @@
+package heuristic
+
+import (
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"sort"
+	"strings"
+)
+
+// Candidate - формула, ожидающая обработки в поиске по первому наилучшему.
+type Candidate struct {
+	Expression expression.Expression
+	Age        int                     // Порядковый номер формулы в поиске
+	Depth      int                     // Глубина вывода формулы
+	Targets    []expression.Expression // Текущие цели поиска
+}
+
+// Heuristic оценивает кандидата: чем меньше оценка, тем раньше он будет обработан.
+type Heuristic interface {
+	Name() string
+	Score(c Candidate) float64
+}
+
+// Size предпочитает формулы меньшего размера.
+type Size struct{}
+
+func (Size) Name() string { return "size" }
+
+func (Size) Score(c Candidate) float64 {
+	return float64(c.Expression.Size())
+}
+
+// Depth предпочитает формулы с меньшей глубиной вывода.
+type Depth struct{}
+
+func (Depth) Name() string { return "depth" }
+
+func (Depth) Score(c Candidate) float64 {
+	return float64(c.Depth)
+}
+
+// Variables предпочитает формулы с меньшим числом различных переменных.
+type Variables struct{}
+
+func (Variables) Name() string { return "vars" }
+
+func (Variables) Score(c Candidate) float64 {
+	distinct := make(map[expression.Value]bool)
+	for _, val := range c.Expression.Variables() {
+		distinct[val] = true
+	}
+	return float64(len(distinct))
+}
+
+// Similarity предпочитает формулы, заключения которых структурно близки к цели.
+// Каждая посылка, которую нужно отделить по modus ponens, увеличивает оценку на единицу.
+type Similarity struct{}
+
+func (Similarity) Name() string { return "similarity" }
+
+func (Similarity) Score(c Candidate) float64 {
+	expr := c.Expression
+	if expr.Empty() || len(c.Targets) == 0 {
+		return float64(expr.Size())
+	}
+
+	best := -1
+	for k := range c.Targets {
+		target := c.Targets[k]
+		if target.Empty() {
+			continue
+		}
+
+		idx := expr.Subtree(0).Self()
+		for premises := 0; ; premises++ {
+			score := premises + distance(&expr, idx, &target, target.Subtree(0).Self())
+			if best < 0 || score < best {
+				best = score
+			}
+
+			if expr.Nodes[idx].Term.Type != expression.Function || expr.Nodes[idx].Term.Op != expression.Implication {
+				break
+			}
+			idx = expr.Subtree(idx).Right()
+		}
+	}
+
+	// Размер различает формулы с одинаковой близостью к цели
+	return float64(best) + float64(expr.Size())/100
+}
+
+// distance считает число несовпадающих узлов при наложении поддерева формулы на поддерево цели.
+// Переменная формулы совпадает с любым поддеревом.
+func distance(expr *expression.Expression, ei uint, target *expression.Expression, ti uint) int {
+	lhs, rhs := expr.Nodes[ei].Term, target.Nodes[ti].Term
+
+	switch {
+	case lhs.Type == expression.Variable:
+		return 0
+	case lhs.Type == expression.Function && rhs.Type == expression.Function && lhs.Op == rhs.Op:
+		return distance(expr, expr.Subtree(ei).Left(), target, target.Subtree(ti).Left()) +
+			distance(expr, expr.Subtree(ei).Right(), target, target.Subtree(ti).Right())
+	case lhs.Type != expression.Function && rhs.Type != expression.Function:
+		if lhs == rhs {
+			return 0
+		}
+		return 1
+	default:
+		return subtreeSize(expr, ei)
+	}
+}
+
+func subtreeSize(expr *expression.Expression, idx uint) int {
+	if !expr.HasLeft(idx) && !expr.HasRight(idx) {
+		return 1
+	}
+	return 1 + subtreeSize(expr, expr.Subtree(idx).Left()) + subtreeSize(expr, expr.Subtree(idx).Right())
+}
+
+var heuristics = map[string]Heuristic{
+	Size{}.Name():       Size{},
+	Depth{}.Name():      Depth{},
+	Variables{}.Name():  Variables{},
+	Similarity{}.Name(): Similarity{},
+}
+
+// Names возвращает имена встроенных эвристик.
+func Names() []string {
+	names := make([]string, 0, len(heuristics))
+	for name := range heuristics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ByName возвращает встроенную эвристику по имени.
+func ByName(name string) (Heuristic, error) {
+	if h, ok := heuristics[strings.ToLower(name)]; ok {
+		return h, nil
+	}
+	return nil, fmt.Errorf("unknown heuristic %q (available: %s)", name, strings.Join(Names(), ", "))
+}
@@ -0,0 +1,77 @@
+package heuristic
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"slices"
+	"testing"
+)
+
+// parse разбирает формулу: строчные имена - константы, заглавные - переменные схемы.
+func parse(t *testing.T, input string) expression.Expression {
+	t.Helper()
+	expr, err := logicparser.ParseSchema(input)
+	if err != nil {
+		t.Fatalf("parse %q: %v", input, err)
+	}
+	return *expr
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		heuristic Heuristic
+		formula   string
+		depth     int
+		target    string
+		want      float64
+	}{
+		{Size{}, "A>(B>A)", 0, "a", 5},
+		{Size{}, "!A", 0, "a", 1},
+		{Depth{}, "A>(B>A)", 3, "a", 3},
+		{Depth{}, "a", 0, "a", 0},
+		{Variables{}, "A>(B>A)", 0, "a", 2},
+		{Variables{}, "(A>B)>(A>C)", 0, "a", 3},
+		{Variables{}, "a>b", 0, "a", 0},
+		// Схема совпадает с целью
+		{Similarity{}, "A>(B>A)", 0, "a>(b>a)", 0.05},
+		// Заключение совпадает с целью после отделения одной посылки
+		{Similarity{}, "a>b", 0, "b", 1.03},
+		// Константа не совпадает с другой константой
+		{Similarity{}, "a>c", 0, "a>b", 1.03},
+		// Атом на месте импликации цели
+		{Similarity{}, "c", 0, "a>b", 1.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heuristic.Name()+" "+tt.formula, func(t *testing.T) {
+			c := Candidate{Expression: parse(t, tt.formula), Depth: tt.depth, Targets: []expression.Expression{parse(t, tt.target)}}
+			if got := tt.heuristic.Score(c); got != tt.want {
+				t.Fatalf("Score = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarityPrefersCloserTarget(t *testing.T) {
+	targets := []expression.Expression{parse(t, "a>(b>a)"), parse(t, "b>a")}
+	near := Similarity{}.Score(Candidate{Expression: parse(t, "B>A"), Targets: targets})
+	far := Similarity{}.Score(Candidate{Expression: parse(t, "c"), Targets: targets})
+	if near >= far {
+		t.Fatalf("Score(B>A) = %v, Score(c) = %v, want the first to be smaller", near, far)
+	}
+}
+
+func TestByName(t *testing.T) {
+	if names := Names(); !slices.Equal(names, []string{"depth", "similarity", "size", "vars"}) {
+		t.Fatalf("Names = %v", names)
+	}
+
+	for _, name := range []string{"size", "SIZE", "vars", "similarity", "depth"} {
+		if _, err := ByName(name); err != nil {
+			t.Errorf("ByName(%q): %v", name, err)
+		}
+	}
+	if _, err := ByName("random"); err == nil {
+		t.Error("ByName accepted an unknown heuristic")
+	}
+}
@@ -0,0 +1,242 @@
+package lemmas
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Файл библиотеки состоит из записей, разделённых пустыми строками:
+//
+//	system <имя> <аксиома>...
+//	lemma <формула>
+//	<формула> <правило> <номер посылки>...
+//
+// Строки шагов перечисляют вывод леммы так, что посылки предшествуют заключениям, номера
+// шагов начинаются с 1. Формулы записываются так, как их печатает String(), как и в файле
+// доказательства: переменные схем - заглавными буквами. Констант в записях нет. Строки,
+// начинающиеся с #, игнорируются.
+
+// Load читает библиотеку системы из файла: к начальным леммам системы добавляются леммы
+// из записей с теми же аксиомами. Выводы всех лемм проверяются. Отсутствующий файл
+// считается пустым. Леммы, добавленные в библиотеку позже, дописываются в файл.
+func Load(path string, system axioms.System) (*Library, error) {
+	l, err := Bootstrap(system)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := readEntries(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !sameAxioms(e.axioms, system.Axioms) {
+			continue
+		}
+
+		root, err := e.proof()
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, e.line, err)
+		}
+		if _, err = l.Add(root); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, e.line, err)
+		}
+	}
+
+	l.path = path
+	return l, nil
+}
+
+// entry - запись файла библиотеки.
+type entry struct {
+	line   int // Номер строки заголовка записи
+	axioms []expression.Expression
+	lemma  expression.Expression
+	steps  []step
+}
+
+type step struct {
+	expression expression.Expression
+	rule       string
+	premises   []int
+}
+
+func readEntries(path string) ([]entry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lemma library: %w", err)
+	}
+	defer file.Close()
+
+	entries := make([]entry, 0)
+	var current *entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case fields[0] == "system":
+			entries = append(entries, entry{line: n})
+			current = &entries[len(entries)-1]
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("%s:%d: system has no axioms", path, n)
+			}
+			for _, formula := range fields[2:] {
+				expr, err := parse(formula)
+				if err != nil {
+					return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+				}
+				current.axioms = append(current.axioms, expr)
+			}
+		case current == nil:
+			return nil, fmt.Errorf("%s:%d: entry does not start with a system line", path, n)
+		case fields[0] == "lemma":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("%s:%d: malformed lemma line", path, n)
+			}
+			if current.lemma, err = parse(fields[1]); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+			}
+		default:
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("%s:%d: malformed proof step", path, n)
+			}
+			s := step{rule: fields[1]}
+			if s.expression, err = parse(fields[0]); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+			}
+			for _, field := range fields[2:] {
+				premise, err := strconv.Atoi(field)
+				if err != nil || premise < 1 || premise > len(current.steps) {
+					return nil, fmt.Errorf("%s:%d: invalid premise %q", path, n, field)
+				}
+				s.premises = append(s.premises, premise-1)
+			}
+			current.steps = append(current.steps, s)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read lemma library: %w", err)
+	}
+	return entries, nil
+}
+
+// proof восстанавливает граф вывода записи; корень - последний шаг.
+func (e entry) proof() (*proof.Node, error) {
+	if len(e.steps) == 0 {
+		return nil, fmt.Errorf("lemma %s has no proof", e.lemma.String())
+	}
+
+	nodes := make([]*proof.Node, 0, len(e.steps))
+	for _, s := range e.steps {
+		node := &proof.Node{Expression: s.expression, Rule: s.rule}
+		for _, premise := range s.premises {
+			node.Premises = append(node.Premises, nodes[premise])
+		}
+		nodes = append(nodes, node)
+	}
+
+	root := nodes[len(nodes)-1]
+	if root.Expression.String() != e.lemma.String() {
+		return nil, fmt.Errorf("proof ends with %s, not with lemma %s", root.Expression.String(), e.lemma.String())
+	}
+	return root, nil
+}
+
+// appendEntry дописывает лемму в файл библиотеки.
+func appendEntry(path string, system axioms.System, lemma Lemma) error {
+	var builder strings.Builder
+	builder.WriteString("system " + system.Name)
+	for i := range system.Axioms {
+		formula, err := format(system.Axioms[i])
+		if err != nil {
+			return err
+		}
+		builder.WriteString(" " + formula)
+	}
+
+	formula, err := format(lemma.Expression)
+	if err != nil {
+		return err
+	}
+	builder.WriteString("\nlemma " + formula + "\n")
+
+	for _, s := range proof.Linearize(lemma.Proof) {
+		if formula, err = format(s.Expression); err != nil {
+			return err
+		}
+		builder.WriteString(formula + " " + s.Rule)
+		for _, premise := range s.Premises {
+			builder.WriteString(" " + strconv.Itoa(premise+1))
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString("\n")
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open lemma library: %w", err)
+	}
+	if _, err = file.WriteString(builder.String()); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write lemma library: %w", err)
+	}
+	return file.Close()
+}
+
+// format записывает схему в виде, который снова читает parse.
+func format(expr expression.Expression) (string, error) {
+	if err := requireSchema(expr); err != nil {
+		return "", err
+	}
+	return expr.String(), nil
+}
+
+func parse(formula string) (expression.Expression, error) {
+	expr, err := logicparser.ParseSchema(formula)
+	if err != nil {
+		return expression.Expression{}, fmt.Errorf("formula %q: %w", formula, err)
+	}
+	if err = requireSchema(*expr); err != nil {
+		return expression.Expression{}, err
+	}
+	expr.Normalize()
+	return *expr, nil
+}
+
+// requireSchema проверяет, что в формуле нет констант: лемма должна быть схемой.
+func requireSchema(expr expression.Expression) error {
+	for _, node := range expr.Nodes {
+		if node.Term.Type == expression.Constant {
+			return fmt.Errorf("formula %s is not a schema: it has constants", expr.String())
+		}
+	}
+	return nil
+}
+
+func sameAxioms(lhs, rhs []expression.Expression) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i := range lhs {
+		if lhs[i].String() != rhs[i].String() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package lemmas
+
+import (
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const record = `system mendelson A>(B>A) (A>(B>C))>((A>B)>(A>C)) (!A>!B)>((!A>B)>A)
+lemma A>(B>B)
+(B>((B>B)>B))>((B>(B>B))>(B>B)) axiom
+B>((B>B)>B) axiom
+(B>(B>B))>(B>B) mp 2 1
+B>(B>B) axiom
+B>B mp 4 3
+(B>B)>(A>(B>B)) axiom
+A>(B>B) mp 5 6
+`
+
+func TestLoad(t *testing.T) {
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lemma, err := logicparser.ParseSchema("A>(B>B)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source.txt")
+	if err = os.WriteFile(source, []byte(record), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(source, system)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, ok := loaded.Find(*lemma)
+	if !ok {
+		t.Fatalf("lemma %s was not loaded", lemma.String())
+	}
+
+	// Лемма, добавленная в библиотеку, дописывается в файл и читается обратно
+	target := filepath.Join(dir, "target.txt")
+	library, err := Load(target, system)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = library.Add(found.Proof); err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(written), "\nlemma A>(B>B)\n") {
+		t.Fatalf("library file does not use schema variables:\n%s", written)
+	}
+	if library, err = Load(target, system); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = library.Find(*lemma); !ok {
+		t.Fatalf("lemma %s was not read back", lemma.String())
+	}
+
+	// Строчные имена - константы, лемма с ними не является схемой
+	constants := filepath.Join(dir, "constants.txt")
+	if err = os.WriteFile(constants, []byte(strings.ReplaceAll(record, "lemma A>(B>B)", "lemma a>(b>b)")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Load(constants, system); err == nil {
+		t.Fatal("Load accepted a lemma with constants")
+	}
+}
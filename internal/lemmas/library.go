@@ -0,0 +1,137 @@
+package lemmas
+
+import (
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/checker"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/tiendc/go-deepcopy"
+)
+
+// Lemma - формула с выводом из аксиом системы, прошедшим проверку.
+type Lemma struct {
+	Expression expression.Expression
+	Proof      *proof.Node
+}
+
+// Library хранит леммы одной системы аксиом. Лемма попадает в библиотеку только
+// вместе с выводом, который принял checker.
+type Library struct {
+	system axioms.System
+	lemmas []Lemma
+	index  map[string]int
+	path   string // Файл, в который дописываются новые леммы
+
+	missing []expression.Expression // Начальные леммы, которые не удалось вывести
+}
+
+// New создаёт пустую библиотеку для системы аксиом.
+func New(system axioms.System) *Library {
+	return &Library{system: system, index: make(map[string]int)}
+}
+
+// Bootstrap создаёт библиотеку из начальных лемм системы (axioms.System.Bootstrap).
+func Bootstrap(system axioms.System) (*Library, error) {
+	l := New(system)
+
+	nodes := make([]*proof.Node, 0, len(system.Axioms))
+	for i := range system.Axioms {
+		nodes = append(nodes, &proof.Node{Expression: system.Axioms[i], Rule: "axiom"})
+	}
+
+	derivations, missing := system.Bootstrap()
+	l.missing = missing
+	for _, derivation := range derivations {
+		node := &proof.Node{
+			Expression: derivation.Expression,
+			Rule:       "mp",
+			Premises:   []*proof.Node{nodes[derivation.Premises[0]], nodes[derivation.Premises[1]]},
+		}
+		nodes = append(nodes, node)
+
+		if derivation.Lemma {
+			if _, err := l.Add(node); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return l, nil
+}
+
+// System возвращает систему аксиом, в которой выведены леммы.
+func (l *Library) System() axioms.System {
+	return l.system
+}
+
+// Add проверяет вывод и добавляет его заключение в библиотеку. Если такая лемма
+// с точностью до переименования переменных уже есть, возвращается она. Библиотека,
+// прочитанная из файла (Load), дописывает новую лемму в файл.
+//
+// Вывод леммы должен быть замкнутым: только аксиомы, modus ponens и правила из реестра,
+// без гипотез и шагов теоремы о дедукции.
+func (l *Library) Add(root *proof.Node) (Lemma, error) {
+	if root == nil {
+		return Lemma{}, fmt.Errorf("empty proof")
+	}
+
+	var expr expression.Expression
+	_ = deepcopy.Copy(&expr, &root.Expression)
+	expr.Normalize()
+
+	steps := proof.Linearize(root)
+	for i, step := range steps {
+		if step.Rule == "hyp" || step.Rule == "deduction" {
+			return Lemma{}, fmt.Errorf("lemma %s: step %d: rule %s is not allowed in a lemma proof",
+				expr.String(), i+1, step.Rule)
+		}
+	}
+
+	if idx, ok := l.index[expr.String()]; ok {
+		return l.lemmas[idx], nil
+	}
+
+	if err := checker.New(l.system.Axioms).Check(steps, expr); err != nil {
+		return Lemma{}, fmt.Errorf("lemma %s: %w", expr.String(), err)
+	}
+
+	lemma := Lemma{Expression: expr, Proof: root}
+	if l.path != "" {
+		if err := appendEntry(l.path, l.system, lemma); err != nil {
+			return Lemma{}, err
+		}
+	}
+
+	l.index[expr.String()] = len(l.lemmas)
+	l.lemmas = append(l.lemmas, lemma)
+	return lemma, nil
+}
+
+// Find возвращает лемму, совпадающую с формулой с точностью до переименования переменных.
+func (l *Library) Find(expr expression.Expression) (Lemma, bool) {
+	var key expression.Expression
+	_ = deepcopy.Copy(&key, &expr)
+	key.Normalize()
+
+	idx, ok := l.index[key.String()]
+	if !ok {
+		return Lemma{}, false
+	}
+	return l.lemmas[idx], true
+}
+
+// Missing возвращает начальные леммы системы, которые не удалось вывести и которых нет в библиотеке.
+func (l *Library) Missing() []expression.Expression {
+	missing := make([]expression.Expression, 0, len(l.missing))
+	for i := range l.missing {
+		if _, ok := l.Find(l.missing[i]); !ok {
+			missing = append(missing, l.missing[i])
+		}
+	}
+	return missing
+}
+
+// Lemmas возвращает леммы в порядке добавления.
+func (l *Library) Lemmas() []Lemma {
+	return l.lemmas
+}
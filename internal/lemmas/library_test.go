@@ -0,0 +1,52 @@
+package lemmas
+
+import (
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := func(formula, rule string, premises ...*proof.Node) *proof.Node {
+		t.Helper()
+		expr, err := logicparser.ParseSchema(formula)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &proof.Node{Expression: *expr, Rule: rule, Premises: premises}
+	}
+
+	distribution := node("(A>((A>A)>A))>((A>(A>A))>(A>A))", "axiom")
+	weakening := node("A>((A>A)>A)", "axiom")
+	step := node("(A>(A>A))>(A>A)", "mp", weakening, distribution)
+	identity := node("A>A", "mp", node("A>(A>A)", "axiom"), step)
+
+	tests := []struct {
+		name  string
+		root  *proof.Node
+		valid bool
+	}{
+		{"closed proof", identity, true},
+		{"hypothesis", node("a>a", "hyp"), false},
+		{"deduction", node("a>a", "deduction", node("a", "hyp")), false},
+		{"hypothesis in a premise", node("b>a", "mp", node("a", "hyp"), node("a>(b>a)", "axiom")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(system).Add(tt.root)
+			if tt.valid && err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatal("Add accepted a proof that is not closed")
+			}
+		})
+	}
+}
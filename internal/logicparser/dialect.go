@@ -0,0 +1,129 @@
+package logicparser
+
+import (
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"slices"
+	"strings"
+)
+
+// Dialect - набор обозначений операций, которые принимает анализатор. Скобки `(` `)`
+// и имена переменных одинаковы во всех диалектах.
+type Dialect struct {
+	Name      string
+	operators map[string]expression.Operation // Символьные обозначения
+	keywords  map[string]expression.Operation // Обозначения словами, не могут быть именами переменных
+}
+
+var (
+	// Classic - исходные односимвольные обозначения: ! | * > + =.
+	Classic = Dialect{
+		Name: "classic",
+		operators: map[string]expression.Operation{
+			"!": expression.Negation,
+			"|": expression.Disjunction,
+			"*": expression.Conjunction,
+			">": expression.Implication,
+			"+": expression.Xor,
+			"=": expression.Equivalent,
+		},
+	}
+
+	// Unicode - математические символы: ¬ ∧ ∨ → ↔ ⊕.
+	Unicode = Dialect{
+		Name: "unicode",
+		operators: map[string]expression.Operation{
+			"¬": expression.Negation,
+			"∨": expression.Disjunction,
+			"∧": expression.Conjunction,
+			"→": expression.Implication,
+			"⊕": expression.Xor,
+			"↔": expression.Equivalent,
+		},
+	}
+
+	// ASCII - стрелки и обозначения из языков программирования: ~ | & -> ^ <->.
+	ASCII = Dialect{
+		Name: "ascii",
+		operators: map[string]expression.Operation{
+			"~":   expression.Negation,
+			"|":   expression.Disjunction,
+			"&":   expression.Conjunction,
+			"->":  expression.Implication,
+			"=>":  expression.Implication,
+			"^":   expression.Xor,
+			"<->": expression.Equivalent,
+			"<=>": expression.Equivalent,
+		},
+	}
+
+	// Words - операции словами: not and or implies iff xor.
+	Words = Dialect{
+		Name: "words",
+		keywords: map[string]expression.Operation{
+			"not":     expression.Negation,
+			"or":      expression.Disjunction,
+			"and":     expression.Conjunction,
+			"implies": expression.Implication,
+			"xor":     expression.Xor,
+			"iff":     expression.Equivalent,
+		},
+	}
+
+	// Any принимает обозначения всех диалектов.
+	Any = merge("any", Classic, Unicode, ASCII, Words)
+)
+
+var dialects = []Dialect{Classic, Unicode, ASCII, Words, Any}
+
+func merge(name string, parts ...Dialect) Dialect {
+	d := Dialect{
+		Name:      name,
+		operators: make(map[string]expression.Operation),
+		keywords:  make(map[string]expression.Operation),
+	}
+	for _, part := range parts {
+		for spelling, op := range part.operators {
+			d.operators[spelling] = op
+		}
+		for spelling, op := range part.keywords {
+			d.keywords[spelling] = op
+		}
+	}
+	return d
+}
+
+// DialectNames возвращает имена встроенных диалектов.
+func DialectNames() []string {
+	names := make([]string, 0, len(dialects))
+	for _, d := range dialects {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+// DialectByName возвращает встроенный диалект по имени.
+func DialectByName(name string) (Dialect, error) {
+	idx := slices.IndexFunc(dialects, func(d Dialect) bool { return d.Name == name })
+	if idx < 0 {
+		return Dialect{}, fmt.Errorf("unknown syntax %q, available: %s", name, strings.Join(DialectNames(), ", "))
+	}
+	return dialects[idx], nil
+}
+
+// operation ищет обозначение операции в начале строки: ключевое слово целиком или
+// самое длинное символьное обозначение.
+func (d Dialect) operation(s string) (string, expression.Operation, bool) {
+	if name := identifier(s); name != "" {
+		op, ok := d.keywords[name]
+		return name, op, ok
+	}
+
+	spelling := ""
+	for candidate := range d.operators {
+		if len(candidate) > len(spelling) && strings.HasPrefix(s, candidate) {
+			spelling = candidate
+		}
+	}
+	return spelling, d.operators[spelling], spelling != ""
+}
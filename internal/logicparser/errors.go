@@ -0,0 +1,102 @@
+package logicparser
+
+import "fmt"
+
+// Language - язык сообщений об ошибках разбора.
+type Language int
+
+const (
+	Russian Language = iota
+	English
+)
+
+// ErrorKind - вид ошибки разбора.
+type ErrorKind int
+
+const (
+	ErrEmpty             ErrorKind = iota // Пустое выражение
+	ErrUnknownSymbol                      // Символ не является ни операцией, ни переменной
+	ErrUnexpectedToken                    // Символ допустим, но не на этом месте
+	ErrUnexpectedEnd                      // Выражение оборвалось
+	ErrUnbalancedBracket                  // Закрывающая скобка без открывающей
+)
+
+// Expectation - что ожидалось на месте ошибки.
+type Expectation int
+
+const (
+	ExpectNothing  Expectation = iota
+	ExpectOperand              // Переменная, отрицание или открывающая скобка
+	ExpectOperator             // Бинарная операция или закрывающая скобка
+	ExpectCloseBracket
+)
+
+var expectations = map[Language]map[Expectation]string{
+	Russian: {
+		ExpectOperand:      "переменная, отрицание или '('",
+		ExpectOperator:     "операция или ')'",
+		ExpectCloseBracket: "')'",
+	},
+	English: {
+		ExpectOperand:      "a variable, negation or '('",
+		ExpectOperator:     "an operator or ')'",
+		ExpectCloseBracket: "')'",
+	},
+}
+
+var messages = map[Language]map[ErrorKind]string{
+	Russian: {
+		ErrEmpty:             "пустое выражение",
+		ErrUnknownSymbol:     "неизвестный символ %q в позиции %d",
+		ErrUnexpectedToken:   "неожиданный символ %q в позиции %d",
+		ErrUnexpectedEnd:     "неожиданный конец выражения в позиции %d",
+		ErrUnbalancedBracket: "лишняя закрывающая скобка в позиции %d",
+	},
+	English: {
+		ErrEmpty:             "empty expression",
+		ErrUnknownSymbol:     "unknown symbol %q at offset %d",
+		ErrUnexpectedToken:   "unexpected %q at offset %d",
+		ErrUnexpectedEnd:     "unexpected end of expression at offset %d",
+		ErrUnbalancedBracket: "unmatched closing bracket at offset %d",
+	},
+}
+
+var expectedPrefix = map[Language]string{
+	Russian: ", ожидалась ",
+	English: ", expected ",
+}
+
+// ParseError - ошибка разбора выражения. Offset - смещение в байтах от начала строки.
+type ParseError struct {
+	Kind     ErrorKind
+	Offset   int
+	Token    string // Символ или имя, на котором произошла ошибка; пустой в конце выражения
+	Expected Expectation
+}
+
+func (e *ParseError) Error() string {
+	return e.Message(Russian)
+}
+
+// Message возвращает описание ошибки на указанном языке.
+func (e *ParseError) Message(lang Language) string {
+	text, ok := messages[lang]
+	if !ok {
+		text = messages[Russian]
+	}
+
+	var msg string
+	switch e.Kind {
+	case ErrEmpty:
+		msg = text[e.Kind]
+	case ErrUnknownSymbol, ErrUnexpectedToken:
+		msg = fmt.Sprintf(text[e.Kind], e.Token, e.Offset)
+	default:
+		msg = fmt.Sprintf(text[e.Kind], e.Offset)
+	}
+
+	if e.Expected != ExpectNothing {
+		msg += expectedPrefix[lang] + expectations[lang][e.Expected]
+	}
+	return msg
+}
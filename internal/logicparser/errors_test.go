@@ -0,0 +1,44 @@
+package logicparser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		kind   ErrorKind
+		offset int
+		ru, en string
+	}{
+		{"", ErrEmpty, 0, "пустое выражение", "empty expression"},
+		{"a>?", ErrUnknownSymbol, 2, `неизвестный символ "?" в позиции 2`, `unknown symbol "?" at offset 2`},
+		{"a b", ErrUnexpectedToken, 2, `неожиданный символ "b" в позиции 2, ожидалась операция или ')'`,
+			`unexpected "b" at offset 2, expected an operator or ')'`},
+		{"a>", ErrUnexpectedEnd, 2, "неожиданный конец выражения в позиции 2, ожидалась переменная, отрицание или '('",
+			"unexpected end of expression at offset 2, expected a variable, negation or '('"},
+		{"(a", ErrUnexpectedEnd, 2, "неожиданный конец выражения в позиции 2, ожидалась ')'",
+			"unexpected end of expression at offset 2, expected ')'"},
+		{"a)", ErrUnbalancedBracket, 1, "лишняя закрывающая скобка в позиции 1", "unmatched closing bracket at offset 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse(%q) error = %v, want *ParseError", tt.input, err)
+			}
+			if parseErr.Kind != tt.kind || parseErr.Offset != tt.offset {
+				t.Fatalf("Parse(%q) = kind %d at %d, want kind %d at %d", tt.input, parseErr.Kind, parseErr.Offset, tt.kind, tt.offset)
+			}
+			if got := parseErr.Message(Russian); got != tt.ru {
+				t.Errorf("Message(Russian) = %q, want %q", got, tt.ru)
+			}
+			if got := parseErr.Message(English); got != tt.en {
+				t.Errorf("Message(English) = %q, want %q", got, tt.en)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package logicparser
 
 import (
-	"fmt"
 	"github.com/spanwalla/logical-inference/internal/expression"
 	"github.com/spanwalla/logical-inference/internal/pkg/stack"
 	"unicode"
@@ -23,12 +22,12 @@ const (
 
 var priority = map[Token]int{
 	Nop:          0,
-	Negation:     5,
-	Implication:  1,
-	Disjunction:  3,
-	Conjunction:  4,
-	Xor:          2,
-	Equivalent:   2,
+	Negation:     expression.Negation.Priority(),
+	Implication:  expression.Implication.Priority(),
+	Disjunction:  expression.Disjunction.Priority(),
+	Conjunction:  expression.Conjunction.Priority(),
+	Xor:          expression.Xor.Priority(),
+	Equivalent:   expression.Equivalent.Priority(),
 	OpenBracket:  0,
 	CloseBracket: 0,
 }
@@ -53,118 +52,147 @@ var opToToken = map[expression.Operation]Token{
 	expression.Equivalent:  Equivalent,
 }
 
-var charToOp = map[rune]expression.Operation{
-	'\x00': expression.Nop,
-	'!':    expression.Negation,
-	'|':    expression.Disjunction,
-	'*':    expression.Conjunction,
-	'>':    expression.Implication,
-	'+':    expression.Xor,
-	'=':    expression.Equivalent,
-}
-
 // LogicParser парсит выражение в список узлов.
 type LogicParser struct {
-	brackets   int
+	dialect    Dialect
+	schema     bool // Строчные имена - константы, заглавные - переменные схем
 	expression string
 	operands   *stack.Stack[expression.Expression]
 	operations *stack.Stack[Token]
 }
 
-// NewLogicParser создает новый анализатор для логических выражений.
+// NewLogicParser создает новый анализатор для логических выражений в классической записи.
 func NewLogicParser(expr string) LogicParser {
+	return NewLogicParserWithDialect(expr, Classic)
+}
+
+// NewLogicParserWithDialect создает анализатор, принимающий обозначения операций диалекта.
+func NewLogicParserWithDialect(expr string, dialect Dialect) LogicParser {
 	return LogicParser{
-		brackets:   0,
+		dialect:    dialect,
 		expression: expr,
 		operands:   stack.New[expression.Expression](),
 		operations: stack.New[Token](),
 	}
 }
 
-// Parse разбивает выражение на узлы (Nodes).
+// Parse разбирает строку в выражение.
+func Parse(expr string) (*expression.Expression, error) {
+	p := NewLogicParser(expr)
+	return p.Parse()
+}
+
+// ParseSchema разбирает формулу в том виде, в котором её печатает String(): строчные имена
+// становятся константами, заглавные имена вида A, B1 - переменными схем.
+func ParseSchema(expr string) (*expression.Expression, error) {
+	p := NewLogicParser(expr)
+	p.schema = true
+	return p.Parse()
+}
+
+// Parse разбивает выражение на узлы (Nodes). Ошибки возвращаются как *ParseError.
 func (p *LogicParser) Parse() (*expression.Expression, error) {
-	lastTokenIsOp := false
-	for _, t := range p.expression {
-		if unicode.IsSpace(t) {
-			continue
-		}
+	p.operands = stack.New[expression.Expression]()
+	p.operations = stack.New[Token]()
 
-		if t == '(' {
-			p.operations.Push(OpenBracket)
-			lastTokenIsOp = false
+	fail := func(kind ErrorKind, offset int, token string, expected Expectation) (*expression.Expression, error) {
+		return expression.NewExpression(), &ParseError{Kind: kind, Offset: offset, Token: token, Expected: expected}
+	}
+
+	// Ожидается операнд (переменная, отрицание, открывающая скобка) или операция
+	expectOperand := true
+	brackets := 0
+	empty := true
+
+	end := 0 // Конец последнего прочитанного имени или обозначения операции
+	for i, t := range p.expression {
+		if i < end || unicode.IsSpace(t) {
 			continue
 		}
+		empty = false
 
-		if t == ')' {
-			if p.operations.Empty() {
-				return expression.NewExpression(), fmt.Errorf("неправильные скобки")
+		spelling, op, isOperation := p.dialect.operation(p.expression[i:])
+		switch {
+		case t == '(':
+			if !expectOperand {
+				return fail(ErrUnexpectedToken, i, string(t), ExpectOperator)
 			}
-
-			for !p.operations.Empty() && *p.operations.Peek() != OpenBracket {
-				err := p.constructNode()
-				if err != nil {
-					return expression.NewExpression(), err
-				}
+			p.operations.Push(OpenBracket)
+			brackets++
+		case t == ')':
+			if expectOperand {
+				return fail(ErrUnexpectedToken, i, string(t), ExpectOperand)
+			}
+			if brackets == 0 {
+				return fail(ErrUnbalancedBracket, i, string(t), ExpectNothing)
 			}
 
+			for *p.operations.Peek() != OpenBracket {
+				p.constructNode()
+			}
 			p.operations.Pop()
-			lastTokenIsOp = false
-			continue
-		}
-
-		if isOperation(t) {
-			op := determineOperation(t)
+			brackets--
+		case isOperation:
+			end = i + len(spelling)
 			if op == expression.Negation {
+				if !expectOperand {
+					return fail(ErrUnexpectedToken, i, spelling, ExpectOperator)
+				}
 				p.operations.Push(opToToken[op])
 				continue
 			}
 
-			if lastTokenIsOp {
-				return expression.NewExpression(), fmt.Errorf("некорректный ввод" +
-					"(несколько операций следуют друг за другом)")
+			if expectOperand {
+				return fail(ErrUnexpectedToken, i, spelling, ExpectOperand)
 			}
 
-			lastTokenIsOp = true
 			for !p.operations.Empty() && priority[*p.operations.Peek()] > priority[opToToken[op]] {
-				err := p.constructNode()
-				if err != nil {
-					return expression.NewExpression(), err
-				}
+				p.constructNode()
 			}
-
 			p.operations.Push(opToToken[op])
-		} else {
-			lastTokenIsOp = false
-			p.operands.Push(*expression.NewExpressionWithTerm(determineOperand(t)))
+			expectOperand = true
+		case isIdentifierStart(t) || p.schema && 'A' <= t && t <= 'Z':
+			name := identifier(p.expression[i:])
+			if !expectOperand {
+				return fail(ErrUnexpectedToken, i, name, ExpectOperator)
+			}
+			term, ok := p.determineOperand(name)
+			if !ok {
+				return fail(ErrUnknownSymbol, i, name, ExpectNothing)
+			}
+			p.operands.Push(*expression.NewExpressionWithTerm(term))
+			expectOperand = false
+			end = i + len(name)
+		default:
+			return fail(ErrUnknownSymbol, i, string(t), ExpectNothing)
 		}
 	}
 
-	for !p.operations.Empty() {
-		err := p.constructNode()
-		if err != nil {
-			return expression.NewExpression(), err
-		}
+	switch {
+	case empty:
+		return fail(ErrEmpty, 0, "", ExpectNothing)
+	case expectOperand:
+		return fail(ErrUnexpectedEnd, len(p.expression), "", ExpectOperand)
+	case brackets > 0:
+		return fail(ErrUnexpectedEnd, len(p.expression), "", ExpectCloseBracket)
 	}
 
-	return p.operands.Peek(), nil
+	for !p.operations.Empty() {
+		p.constructNode()
+	}
+	return p.operands.Pop(), nil
 }
 
-// constructNode создает новый узел из текущих операций и операндов.
-func (p *LogicParser) constructNode() error {
+// constructNode создает новый узел из текущих операций и операндов. Порядок символов
+// проверяется при разборе, поэтому операндов всегда достаточно.
+func (p *LogicParser) constructNode() {
 	if *p.operations.Peek() == Negation {
 		operand := *p.operands.Pop()
 		p.operations.Pop()
 
 		operand.Negation(0)
 		p.operands.Push(operand)
-		return nil
-	}
-
-	if p.operands.Len() < 2 || p.operations.Empty() {
-		if *p.operations.Peek() == OpenBracket || *p.operations.Peek() == CloseBracket {
-			return fmt.Errorf("неправильные скобки")
-		}
-		return fmt.Errorf("что-то пошло не так при формировании узла")
+		return
 	}
 
 	// Извлекаем узлы
@@ -173,31 +201,32 @@ func (p *LogicParser) constructNode() error {
 	lhs := *p.operands.Pop()
 
 	p.operands.Push(expression.Construct(lhs, tokenToOperation[op], rhs))
-	return nil
 }
 
-// isOperation проверяет, является ли символ оператором.
-func isOperation(token rune) bool {
-	_, ok := charToOp[token]
-	return ok
+// isIdentifierStart проверяет, может ли символ начинать имя переменной. Имена начинаются
+// со строчной латинской буквы, чтобы не совпадать с заглавными именами переменных схем при печати.
+func isIdentifierStart(token rune) bool {
+	return 'a' <= token && token <= 'z'
 }
 
-// determineOperation определяет операцию из символа.
-func determineOperation(token rune) expression.Operation {
-	if op, ok := charToOp[token]; ok {
-		return op
+// identifier возвращает имя в начале строки: буквы, цифры и '_'.
+func identifier(s string) string {
+	for i, t := range s {
+		if !('a' <= t && t <= 'z' || 'A' <= t && t <= 'Z' || '0' <= t && t <= '9' || t == '_') {
+			return s[:i]
+		}
 	}
-	return expression.Nop
+	return s
 }
 
-func determineOperand(token rune) expression.Term {
-	if !('a' <= token && token <= 'z') {
-		panic("неправильное имя переменной")
+func (p *LogicParser) determineOperand(name string) (expression.Term, bool) {
+	if !p.schema {
+		return expression.Term{Type: expression.Variable, Op: expression.Nop, Val: expression.Symbols.Intern(name)}, true
 	}
 
-	return expression.Term{
-		Type: expression.Variable,
-		Op:   expression.Nop,
-		Val:  expression.Value(token - 'a' + 1),
+	if isIdentifierStart(rune(name[0])) {
+		return expression.Term{Type: expression.Constant, Op: expression.Nop, Val: expression.Symbols.Intern(name)}, true
 	}
+	val, ok := expression.GeneratedValue(name)
+	return expression.Term{Type: expression.Variable, Op: expression.Nop, Val: val}, ok
 }
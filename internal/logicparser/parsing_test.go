@@ -0,0 +1,141 @@
+package logicparser
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	// Parse делает все имена переменными, поэтому они печатаются заглавными
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"a", "A"},
+		{"!a", "!A"},
+		{"!!a", "A"},
+		{"a>b>c", "A>(B>C)"},
+		{"(a>b)>c", "(A>B)>C"},
+		{"a|b*c", "A|(B*C)"},
+		{"(a|b)*c", "(A|B)*C"},
+		{"!(a>b)", "A*!B"},
+		{"a = b + c", "A=(B+C)"},
+		{" ( a ) ", "A"},
+		{"p1>p", "P1>P"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if got := expr.String(); got != tt.want {
+				t.Fatalf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"a>A", "a>A"},
+		{"!B1>(c*C)", "!B1>(c*C)"},
+		{"A>(B>A)", "A>(B>A)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := ParseSchema(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSchema(%q): %v", tt.input, err)
+			}
+			if got := expr.String(); got != tt.want {
+				t.Fatalf("ParseSchema(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseSchema("A0"); err == nil {
+		t.Fatal("ParseSchema accepted variable A0")
+	}
+	if _, err := Parse("A"); err == nil {
+		t.Fatal("Parse accepted an uppercase name")
+	}
+}
+
+func TestDialects(t *testing.T) {
+	tests := []struct {
+		dialect string
+		input   string
+		want    string // Пустая строка - ошибка разбора
+	}{
+		{"classic", "!a>(b*c|d)", "!A>((B*C)|D)"},
+		{"classic", "a->b", ""},
+		{"unicode", "¬a → (b ∧ c ∨ d)", "!A>((B*C)|D)"},
+		{"unicode", "a ↔ b ⊕ c", "A=(B+C)"},
+		{"unicode", "a>b", ""},
+		{"ascii", "~a -> (b & c | d)", "!A>((B*C)|D)"},
+		{"ascii", "a => b <-> c ^ d", "A>(B=(C+D))"},
+		{"ascii", "a <=> b", "A=B"},
+		{"words", "not a implies (b and c or d)", "!A>((B*C)|D)"},
+		{"words", "a iff b xor c", "A=(B+C)"},
+		{"words", "and implies b", ""},
+		{"any", "¬a -> (b and c | d)", "!A>((B*C)|D)"},
+		{"any", "a > b → c", "A>(B>C)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect+" "+tt.input, func(t *testing.T) {
+			dialect, err := DialectByName(tt.dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p := NewLogicParserWithDialect(tt.input, dialect)
+			expr, err := p.Parse()
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %s, want an error", tt.input, expr.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if got := expr.String(); got != tt.want {
+				t.Fatalf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := DialectByName("latex"); err == nil {
+		t.Fatal("DialectByName accepted an unknown dialect")
+	}
+}
+
+func TestStandardizeDialects(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		input   string
+		want    string
+	}{
+		{Classic, "a=b", "(a>b)*(b>a)"},
+		{ASCII, "a <-> b", "(a>b)*(b>a)"},
+		{Unicode, "a ↔ b", "(a>b)*(b>a)"},
+		{Classic, "a+b", "(a>b)>(b*!a)"},
+		{ASCII, "a ^ b", "(a>b)>(b*!a)"},
+		{Unicode, "a ⊕ b", "(a>b)>(b*!a)"},
+		{Words, "a xor b", "(a>b)>(b*!a)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect.Name+" "+tt.input, func(t *testing.T) {
+			expr := constant(t, tt.input, tt.dialect)
+			expr.Standardize()
+			if got := expr.String(); got != tt.want {
+				t.Fatalf("Standardize(%s) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package logicparser
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"testing"
+)
+
+// constant разбирает формулу и делает её имена константами, чтобы они печатались так,
+// как записаны.
+func constant(t *testing.T, input string, dialect Dialect) expression.Expression {
+	t.Helper()
+	p := NewLogicParserWithDialect(input, dialect)
+	expr, err := p.Parse()
+	if err != nil {
+		t.Fatalf("parse %q: %v", input, err)
+	}
+	expr.MakeConst()
+	return *expr
+}
+
+func TestPrinterRoundTrip(t *testing.T) {
+	formulas := []string{
+		"a",
+		"!a>b",
+		"a>(b>c)",
+		"(a>b)>c",
+		"(a|b)*!c",
+		"a|(b*c)",
+		"!(a|b)=(c+d)",
+		"((a>b)>a)>a",
+		"p1*long_name",
+	}
+	printers := []expression.Printer{
+		{},
+		{MinimalParentheses: true},
+		{Notation: expression.UnicodeNotation},
+		{Notation: expression.UnicodeNotation, MinimalParentheses: true},
+	}
+
+	for _, formula := range formulas {
+		expr := constant(t, formula, Classic)
+		for _, printer := range printers {
+			printed := printer.Print(&expr)
+			parsed := constant(t, printed, Any)
+			if parsed.String() != expr.String() {
+				t.Errorf("%+v: %s printed as %q, read back as %s", printer, expr.String(), printed, parsed.String())
+			}
+		}
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	tests := []struct {
+		input   string
+		printer expression.Printer
+		want    string
+	}{
+		{"a>(b>c)", expression.Printer{MinimalParentheses: true}, "a>b>c"},
+		{"(a>b)>c", expression.Printer{MinimalParentheses: true}, "(a>b)>c"},
+		{"a|(b*c)", expression.Printer{MinimalParentheses: true}, "a|b*c"},
+		{"!a>(b*c)", expression.Printer{Notation: expression.UnicodeNotation}, "¬a → (b ∧ c)"},
+		{"!a>(b|p1)", expression.Printer{Notation: expression.LaTeXNotation}, `\neg a \to (b \lor p_{1})`},
+		{"a=(b+c)", expression.Printer{Notation: expression.LaTeXNotation, MinimalParentheses: true}, `a \leftrightarrow b \oplus c`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr := constant(t, tt.input, Classic)
+			if got := tt.printer.Print(&expr); got != tt.want {
+				t.Fatalf("Print(%s) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package priorityqueue
+
+import "container/heap"
+
+type PriorityQueue[T any] struct {
+	data *items[T]
+}
+
+// New создаёт очередь, в которой первым извлекается наименьший по less элемент.
+func New[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		data: &items[T]{less: less},
+	}
+}
+
+func (q *PriorityQueue[T]) Push(value T) {
+	heap.Push(q.data, value)
+}
+
+func (q *PriorityQueue[T]) Pop() *T {
+	if q.data.Len() == 0 {
+		return nil
+	}
+	value := heap.Pop(q.data).(T)
+	return &value
+}
+
+func (q *PriorityQueue[T]) Peek() *T {
+	if q.data.Len() == 0 {
+		return nil
+	}
+	value := q.data.values[0]
+	return &value
+}
+
+func (q *PriorityQueue[T]) Empty() bool {
+	return q.data.Len() == 0
+}
+
+func (q *PriorityQueue[T]) Len() int {
+	return q.data.Len()
+}
+
+// items реализует heap.Interface.
+type items[T any] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (h *items[T]) Len() int {
+	return len(h.values)
+}
+
+func (h *items[T]) Less(i, j int) bool {
+	return h.less(h.values[i], h.values[j])
+}
+
+func (h *items[T]) Swap(i, j int) {
+	h.values[i], h.values[j] = h.values[j], h.values[i]
+}
+
+func (h *items[T]) Push(x any) {
+	h.values = append(h.values, x.(T))
+}
+
+func (h *items[T]) Pop() any {
+	last := len(h.values) - 1
+	value := h.values[last]
+	var zero T
+	h.values[last] = zero
+	h.values = h.values[:last]
+	return value
+}
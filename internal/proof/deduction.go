@@ -0,0 +1,182 @@
+package proof
+
+import (
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/helper"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/rules"
+)
+
+// Схемы аксиом, через которые выражается теорема о дедукции
+const (
+	WeakeningSchema    = "a>(b>a)"
+	DistributionSchema = "(a>(b>c))>((a>b)>(a>c))"
+)
+
+// RequireDeductionAxioms проверяет, что обе схемы теоремы о дедукции с точностью
+// до переименования переменных являются аксиомами.
+func RequireDeductionAxioms(axioms []expression.Expression) error {
+	for _, formula := range []string{WeakeningSchema, DistributionSchema} {
+		schema, err := logicparser.Parse(formula)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for i := range axioms {
+			if helper.IsInstance(axioms[i], *schema) && helper.IsInstance(*schema, axioms[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no axiom %s", schema.String())
+		}
+	}
+	return nil
+}
+
+// Deduction по выводу root из гипотез, среди которых есть hypothesis (вершины с правилом "hyp"),
+// строит вывод hypothesis → root без этой гипотезы. Помимо modus ponens используются только
+// частные случаи схем аксиом A>(B>A) и (A>(B>C))>((A>B)>(A>C)), поэтому обе схемы должны
+// быть аксиомами системы.
+//
+// Каждая формула φ вывода заменяется на hypothesis → φ: сама гипотеза - на доказательство A>A,
+// формулы, не зависящие от гипотезы, - на modus ponens с A>(B>A), а шаги modus ponens - на два
+// шага со второй схемой. Вершины исходного графа не изменяются.
+func Deduction(root *Node, hypothesis expression.Expression) (*Node, error) {
+	d := &deduction{
+		hypothesis: hypothesis,
+		key:        hypothesis.String(),
+		uses:       make(map[*Node]bool),
+		nodes:      make(map[*Node]*Node),
+		known:      make(map[string]*Node),
+	}
+	return d.transform(root)
+}
+
+type deduction struct {
+	hypothesis expression.Expression
+	key        string
+	identity   *Node
+	uses       map[*Node]bool  // Зависит ли вершина от гипотезы
+	nodes      map[*Node]*Node // Преобразованные вершины
+	known      map[string]*Node
+}
+
+// dependsOnHypothesis проверяет, используется ли гипотеза в выводе вершины.
+func (d *deduction) dependsOnHypothesis(node *Node) bool {
+	if uses, ok := d.uses[node]; ok {
+		return uses
+	}
+
+	uses := node.Rule == "hyp" && node.Expression.String() == d.key
+	for _, premise := range node.Premises {
+		uses = uses || d.dependsOnHypothesis(premise)
+	}
+	d.uses[node] = uses
+	return uses
+}
+
+func (d *deduction) transform(node *Node) (*Node, error) {
+	if result, ok := d.nodes[node]; ok {
+		return result, nil
+	}
+
+	var result *Node
+	var err error
+	switch {
+	case !d.dependsOnHypothesis(node):
+		// φ, φ>(A>φ) ⊢ A>φ
+		result, err = d.mp(node, d.weakening(node.Expression, d.hypothesis))
+	case len(node.Premises) == 0:
+		result, err = d.selfImplication()
+	case node.Rule == "mp" && len(node.Premises) == 2:
+		// A>ψ, A>(ψ>φ) ⊢ A>φ
+		var minor, major, distributed *Node
+		if minor, err = d.transform(node.Premises[0]); err != nil {
+			return nil, err
+		}
+		if major, err = d.transform(node.Premises[1]); err != nil {
+			return nil, err
+		}
+		if distributed, err = d.mp(major, d.distribution(major.Expression)); err != nil {
+			return nil, err
+		}
+		result, err = d.mp(minor, distributed)
+	default:
+		return nil, fmt.Errorf("cannot eliminate hypothesis from rule %q in %s", node.Rule, node.Expression.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.nodes[node] = result
+	return result, nil
+}
+
+// selfImplication строит вывод A>A для гипотезы A.
+func (d *deduction) selfImplication() (*Node, error) {
+	if d.identity != nil {
+		return d.identity, nil
+	}
+
+	a := d.hypothesis
+	aa := expression.Construct(a, expression.Implication, a)
+
+	// A>((A>A)>A), (A>((A>A)>A))>((A>(A>A))>(A>A)) ⊢ (A>(A>A))>(A>A)
+	first := d.weakening(a, aa)
+	distributed, err := d.mp(first, d.distribution(first.Expression))
+	if err != nil {
+		return nil, err
+	}
+
+	// A>(A>A) ⊢ A>A
+	if d.identity, err = d.mp(d.weakening(a, a), distributed); err != nil {
+		return nil, err
+	}
+	return d.identity, nil
+}
+
+// weakening возвращает аксиому a>(b>a).
+func (d *deduction) weakening(a, b expression.Expression) *Node {
+	inner := expression.Construct(b, expression.Implication, a)
+	return d.axiom(expression.Construct(a, expression.Implication, inner))
+}
+
+// distribution по формуле a>(b>c) возвращает аксиому (a>(b>c))>((a>b)>(a>c)).
+func (d *deduction) distribution(expr expression.Expression) *Node {
+	a := *expr.CopySubtree(expr.Subtree(0).Left())
+	bc := *expr.CopySubtree(expr.Subtree(0).Right())
+	b := *bc.CopySubtree(bc.Subtree(0).Left())
+	c := *bc.CopySubtree(bc.Subtree(0).Right())
+
+	ab := expression.Construct(a, expression.Implication, b)
+	ac := expression.Construct(a, expression.Implication, c)
+	return d.axiom(expression.Construct(expr, expression.Implication, expression.Construct(ab, expression.Implication, ac)))
+}
+
+func (d *deduction) axiom(expr expression.Expression) *Node {
+	return d.add(&Node{Expression: expr, Rule: "axiom"})
+}
+
+// mp применяет modus ponens к вершинам.
+func (d *deduction) mp(minor, major *Node) (*Node, error) {
+	expr := *rules.ApplyModusPonens(minor.Expression, major.Expression)
+	if expr.Empty() {
+		return nil, fmt.Errorf("modus ponens is not applicable to %s and %s",
+			minor.Expression.String(), major.Expression.String())
+	}
+	return d.add(&Node{Expression: expr, Rule: "mp", Premises: []*Node{minor, major}}), nil
+}
+
+// add объединяет одинаковые формулы, полученные при преобразовании.
+func (d *deduction) add(node *Node) *Node {
+	key := node.Expression.String()
+	if existing, ok := d.known[key]; ok {
+		return existing
+	}
+	d.known[key] = node
+	return node
+}
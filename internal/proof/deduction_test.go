@@ -0,0 +1,69 @@
+package proof_test
+
+import (
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/checker"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/helper"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"testing"
+)
+
+func formula(t *testing.T, input string) expression.Expression {
+	t.Helper()
+	expr, err := logicparser.ParseSchema(input)
+	if err != nil {
+		t.Fatalf("parse %q: %v", input, err)
+	}
+	return *expr
+}
+
+func TestDeduction(t *testing.T) {
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a, a>b ⊢ A>b; шаг A>(B>A) от гипотез не зависит
+	a := &proof.Node{Expression: formula(t, "a"), Rule: "hyp"}
+	ab := &proof.Node{Expression: formula(t, "a>b"), Rule: "hyp"}
+	b := &proof.Node{Expression: formula(t, "b"), Rule: "mp", Premises: []*proof.Node{a, ab}}
+	weakening := &proof.Node{Expression: formula(t, "A>(B>A)"), Rule: "axiom"}
+	root := &proof.Node{Expression: formula(t, "A>b"), Rule: "mp", Premises: []*proof.Node{b, weakening}}
+
+	tests := []struct {
+		eliminated []string // Исключаются с конца
+		remaining  []string
+		want       string
+	}{
+		{[]string{"a"}, []string{"a>b"}, "a>(A>b)"},
+		{[]string{"a>b"}, []string{"a"}, "(a>b)>(A>b)"},
+		{[]string{"a>b", "a"}, nil, "(a>b)>(a>(A>b))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			result := root
+			for i := len(tt.eliminated) - 1; i >= 0; i-- {
+				if result, err = proof.Deduction(result, formula(t, tt.eliminated[i])); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want := formula(t, tt.want)
+			if !helper.IsInstance(result.Expression, want) {
+				t.Fatalf("Deduction proves %s, want %s", result.Expression.String(), want.String())
+			}
+
+			// Исключённая гипотеза, оставшаяся в выводе, была бы неснятым допущением
+			remaining := make([]expression.Expression, 0, len(tt.remaining))
+			for _, hypothesis := range tt.remaining {
+				remaining = append(remaining, formula(t, hypothesis))
+			}
+			if err = checker.New(system.Axioms, remaining...).Check(proof.Linearize(result), want); err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package proof
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Document - доказательство в текстовом виде, которое можно сохранить и проверить отдельно:
+//
+//	hyp <формула>
+//	target <формула>
+//	<формула> <правило> <номер посылки>...
+//
+// Шаги нумеруются с 1 и ссылаются только на предыдущие шаги. Шаг hyp с формулой, которой нет
+// среди строк hyp, - допущение, которое снимает шаг deduction. Формулы записываются так,
+// как их печатает String(): константы строчными именами, переменные схем - заглавными.
+// Строки, начинающиеся с #, игнорируются.
+type Document struct {
+	Hypotheses []expression.Expression
+	Target     expression.Expression
+	Steps      []Step
+}
+
+// WriteDocument записывает доказательство.
+func WriteDocument(w io.Writer, doc Document) error {
+	writer := bufio.NewWriter(w)
+	for i := range doc.Hypotheses {
+		_, _ = fmt.Fprintln(writer, "hyp", doc.Hypotheses[i].String())
+	}
+	_, _ = fmt.Fprintln(writer, "target", doc.Target.String())
+
+	for _, step := range doc.Steps {
+		parts := make([]string, 0, len(step.Premises)+2)
+		parts = append(parts, step.Expression.String(), step.Rule)
+		for _, premise := range step.Premises {
+			parts = append(parts, strconv.Itoa(premise+1))
+		}
+		_, _ = fmt.Fprintln(writer, strings.Join(parts, " "))
+	}
+	return writer.Flush()
+}
+
+// ReadDocument читает доказательство. Проверяется только запись; корректность вывода
+// проверяет checker.
+func ReadDocument(r io.Reader) (Document, error) {
+	var doc Document
+	hasTarget := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return Document{}, fmt.Errorf("line %d: expected a formula and a rule", n)
+		}
+
+		switch fields[0] {
+		case "hyp", "target":
+			if len(fields) != 2 {
+				return Document{}, fmt.Errorf("line %d: malformed %s line", n, fields[0])
+			}
+			expr, err := logicparser.ParseSchema(fields[1])
+			if err != nil {
+				return Document{}, fmt.Errorf("line %d: %w", n, err)
+			}
+			if fields[0] == "hyp" {
+				doc.Hypotheses = append(doc.Hypotheses, *expr)
+			} else {
+				doc.Target, hasTarget = *expr, true
+			}
+		default:
+			expr, err := logicparser.ParseSchema(fields[0])
+			if err != nil {
+				return Document{}, fmt.Errorf("line %d: %w", n, err)
+			}
+
+			step := Step{Expression: *expr, Rule: fields[1], Premises: make([]int, 0, len(fields)-2)}
+			for _, field := range fields[2:] {
+				premise, err := strconv.Atoi(field)
+				if err != nil || premise < 1 {
+					return Document{}, fmt.Errorf("line %d: invalid premise %q", n, field)
+				}
+				step.Premises = append(step.Premises, premise-1)
+			}
+			doc.Steps = append(doc.Steps, step)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Document{}, err
+	}
+
+	if len(doc.Steps) == 0 {
+		return Document{}, fmt.Errorf("proof has no steps")
+	}
+	if !hasTarget {
+		doc.Target = doc.Steps[len(doc.Steps)-1].Expression
+	}
+	return doc, nil
+}
@@ -0,0 +1,113 @@
+package proof
+
+import (
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/rules"
+)
+
+// Expand заменяет шаги производных правил выводом по modus ponens: посылки шага по порядку
+// отделяются от леммы правила, вывод которой возвращает lemma. Вершины исходного графа не изменяются.
+func Expand(root *Node, lemma func(rule rules.Justified) (*Node, error)) (*Node, error) {
+	expanded := make(map[*Node]*Node)
+
+	var expand func(node *Node) (*Node, error)
+	expand = func(node *Node) (*Node, error) {
+		if result, ok := expanded[node]; ok {
+			return result, nil
+		}
+
+		premises := make([]*Node, 0, len(node.Premises))
+		changed := false
+		for _, premise := range node.Premises {
+			result, err := expand(premise)
+			if err != nil {
+				return nil, err
+			}
+			premises = append(premises, result)
+			changed = changed || result != premise
+		}
+
+		result := node
+		switch node.Rule {
+		case "axiom", "hyp", "mp":
+			if changed {
+				result = &Node{Expression: node.Expression, Rule: node.Rule, Premises: premises}
+			}
+		default:
+			rule, err := rules.ByName(node.Rule)
+			if err != nil {
+				return nil, err
+			}
+			justified, ok := rule.(rules.Justified)
+			if !ok {
+				return nil, fmt.Errorf("rule %q has no justification by modus ponens", node.Rule)
+			}
+
+			if result, err = lemma(justified); err != nil {
+				return nil, err
+			}
+			for _, premise := range premises {
+				expr := *rules.ApplyModusPonens(premise.Expression, result.Expression)
+				if expr.Empty() {
+					return nil, fmt.Errorf("modus ponens is not applicable to %s and %s",
+						premise.Expression.String(), result.Expression.String())
+				}
+				result = &Node{Expression: expr, Rule: "mp", Premises: []*Node{premise, result}}
+			}
+		}
+
+		expanded[node] = result
+		return result, nil
+	}
+	return expand(root)
+}
+
+// Generalize заменяет константы во всех формулах вывода переменными, одинаковыми для всего
+// вывода. Вывод частного случая схемы из аксиом по modus ponens становится выводом самой схемы.
+func Generalize(root *Node) *Node {
+	var bound expression.Value
+	visited := make(map[*Node]bool)
+	var scan func(node *Node)
+	scan = func(node *Node) {
+		if visited[node] {
+			return
+		}
+		visited[node] = true
+		for _, n := range node.Expression.Nodes {
+			if n.Term.Type == expression.Variable {
+				bound = max(bound, n.Term.Val)
+			}
+		}
+		for _, premise := range node.Premises {
+			scan(premise)
+		}
+	}
+	scan(root)
+
+	generalized := make(map[*Node]*Node)
+	var generalize func(node *Node) *Node
+	generalize = func(node *Node) *Node {
+		if result, ok := generalized[node]; ok {
+			return result
+		}
+
+		nodes := make([]expression.Node, len(node.Expression.Nodes))
+		copy(nodes, node.Expression.Nodes)
+		for i := range nodes {
+			if nodes[i].Term.Type == expression.Constant {
+				nodes[i].Term.Type = expression.Variable
+				nodes[i].Term.Val += bound
+			}
+		}
+
+		result := &Node{Expression: *expression.NewExpressionWithNodes(nodes), Rule: node.Rule}
+		result.Expression.Normalize()
+		for _, premise := range node.Premises {
+			result.Premises = append(result.Premises, generalize(premise))
+		}
+		generalized[node] = result
+		return result
+	}
+	return generalize(root)
+}
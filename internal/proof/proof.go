@@ -0,0 +1,86 @@
+package proof
+
+import "github.com/spanwalla/logical-inference/internal/expression"
+
+// Step - шаг линейного доказательства.
+type Step struct {
+	Expression expression.Expression
+	Rule       string
+	Premises   []int // Индексы посылок в списке шагов
+}
+
+// Linearize упорядочивает вывод корня в список шагов так, чтобы посылки предшествовали
+// заключениям. Сначала идут аксиомы, затем остальные вершины уровнями дерева зависимостей,
+// начиная с самого удалённого от корня.
+func Linearize(root *Node) []Step {
+	if root == nil {
+		return nil
+	}
+
+	// Обход в глубину: посылки раньше заключений
+	order := make([]*Node, 0)
+	position := make(map[*Node]int)
+	var visit func(node *Node)
+	visit = func(node *Node) {
+		if _, ok := position[node]; ok {
+			return
+		}
+		position[node] = -1
+		for _, premise := range node.Premises {
+			visit(premise)
+		}
+		position[node] = len(order)
+		order = append(order, node)
+	}
+	visit(root)
+
+	// Уровень вершины - длина наибольшего пути от корня
+	depth := make(map[*Node]int, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		for _, premise := range order[i].Premises {
+			depth[premise] = max(depth[premise], depth[order[i]]+1)
+		}
+	}
+
+	treeLevels := make([][]*Node, 0)
+	for _, node := range order {
+		for len(treeLevels) <= depth[node] {
+			treeLevels = append(treeLevels, make([]*Node, 0))
+		}
+		treeLevels[depth[node]] = append(treeLevels[depth[node]], node)
+	}
+
+	indices := make(map[*Node]int, len(order))
+	sequence := make([]*Node, 0, len(order))
+	add := func(node *Node) {
+		if _, exists := indices[node]; exists {
+			return
+		}
+		indices[node] = len(sequence)
+		sequence = append(sequence, node)
+	}
+
+	for _, level := range treeLevels {
+		for _, node := range level {
+			if len(node.Premises) == 0 {
+				add(node)
+			}
+		}
+	}
+
+	for i := len(treeLevels) - 1; i >= 0; i-- {
+		for _, node := range treeLevels[i] {
+			add(node)
+		}
+	}
+
+	steps := make([]Step, 0, len(sequence))
+	for _, node := range sequence {
+		premises := make([]int, 0, len(node.Premises))
+		for _, premise := range node.Premises {
+			premises = append(premises, indices[premise])
+		}
+		steps = append(steps, Step{Expression: node.Expression, Rule: node.Rule, Premises: premises})
+	}
+	return steps
+}
@@ -0,0 +1,103 @@
+package proof
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteDOT записывает граф доказательства на языке Graphviz DOT. Вершины нумеруются так же,
+// как шаги; рёбра идут от посылок к заключению. Аксиомы, гипотезы, шаги modus ponens,
+// шаги теоремы о дедукции и шаги других правил оформлены по-разному.
+func WriteDOT(w io.Writer, steps []Step, printer expression.Printer) error {
+	writer := bufio.NewWriter(w)
+	_, _ = fmt.Fprintln(writer, "digraph proof {")
+	_, _ = fmt.Fprintln(writer, `	node [fontname="monospace"];`)
+
+	for i, step := range steps {
+		label := fmt.Sprintf("%d. %s\\n%s", i+1, escapeDOT(printer.Print(&step.Expression)), escapeDOT(step.Rule))
+		_, _ = fmt.Fprintf(writer, "\tn%d [label=\"%s\", %s];\n", i+1, label, nodeStyle(step.Rule))
+	}
+
+	for i, step := range steps {
+		for k, premise := range step.Premises {
+			// У modus ponens первая посылка - φ, вторая - φ>ψ
+			attrs := ""
+			if step.Rule == "mp" && k == 1 {
+				attrs = " [style=dashed]"
+			}
+			_, _ = fmt.Fprintf(writer, "\tn%d -> n%d%s;\n", premise+1, i+1, attrs)
+		}
+	}
+
+	_, _ = fmt.Fprintln(writer, "}")
+	return writer.Flush()
+}
+
+func nodeStyle(rule string) string {
+	switch rule {
+	case "axiom":
+		return `shape=box, style=filled, fillcolor="lightblue"`
+	case "hyp":
+		return `shape=box, style="filled,rounded", fillcolor="lightyellow"`
+	case "mp":
+		return `shape=ellipse`
+	case "deduction":
+		return `shape=ellipse, style=filled, fillcolor="palegreen"`
+	default:
+		return `shape=ellipse, style=filled, fillcolor="lightgrey"`
+	}
+}
+
+func escapeDOT(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// WriteTree печатает доказательство деревом с отступами: заключение над своими посылками.
+// Общий для нескольких шагов подвывод печатается один раз, повторно - только ссылкой на номер.
+func WriteTree(w io.Writer, steps []Step, printer expression.Printer) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	writer := bufio.NewWriter(w)
+	printed := make(map[int]bool, len(steps))
+
+	var visit func(idx int, prefix, branch, indent string)
+	visit = func(idx int, prefix, branch, indent string) {
+		step := steps[idx]
+		line := fmt.Sprintf("%d. %s: %s", idx+1, describeRule(step), printer.Print(&step.Expression))
+		if printed[idx] && len(step.Premises) > 0 {
+			_, _ = fmt.Fprintf(writer, "%s%s%s (see above)\n", prefix, branch, line)
+			return
+		}
+		printed[idx] = true
+		_, _ = fmt.Fprintf(writer, "%s%s%s\n", prefix, branch, line)
+
+		for k, premise := range step.Premises {
+			if k == len(step.Premises)-1 {
+				visit(premise, prefix+indent, "`-- ", "    ")
+			} else {
+				visit(premise, prefix+indent, "|-- ", "|   ")
+			}
+		}
+	}
+	visit(len(steps)-1, "", "", "")
+	return writer.Flush()
+}
+
+// describeRule записывает правило шага вместе с номерами посылок, как в ThoughtChain.
+func describeRule(step Step) string {
+	if len(step.Premises) == 0 {
+		return step.Rule
+	}
+
+	premises := make([]string, 0, len(step.Premises))
+	for _, premise := range step.Premises {
+		premises = append(premises, strconv.Itoa(premise+1))
+	}
+	return fmt.Sprintf("%s(%s)", step.Rule, strings.Join(premises, ","))
+}
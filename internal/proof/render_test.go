@@ -0,0 +1,57 @@
+package proof
+
+import (
+	"bytes"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"strings"
+	"testing"
+)
+
+// deductionSteps - вывод a>(b>a): гипотеза a и два шага deduction.
+func deductionSteps(t *testing.T) []Step {
+	t.Helper()
+	steps := make([]Step, 0, 3)
+	for i, line := range []string{"a", "b>a", "a>(b>a)"} {
+		expr, err := logicparser.ParseSchema(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		step := Step{Expression: *expr, Rule: "hyp"}
+		if i > 0 {
+			step.Rule, step.Premises = "deduction", []int{i - 1}
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := WriteDOT(&buffer, deductionSteps(t), expression.Printer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`n1 [label="1. a\nhyp", shape=box, style="filled,rounded", fillcolor="lightyellow"];`,
+		`n3 [label="3. a>(b>a)\ndeduction", shape=ellipse, style=filled, fillcolor="palegreen"];`,
+		"n1 -> n2;",
+		"n2 -> n3;",
+	} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Errorf("DOT output has no %q:\n%s", want, buffer.String())
+		}
+	}
+}
+
+func TestWriteTree(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := WriteTree(&buffer, deductionSteps(t), expression.Printer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "3. deduction(2): a>(b>a)\n`-- 2. deduction(1): b>a\n    `-- 1. hyp: a\n"
+	if buffer.String() != want {
+		t.Fatalf("tree =\n%s\nwant\n%s", buffer.String(), want)
+	}
+}
@@ -0,0 +1,103 @@
+package proof
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"os"
+	"strings"
+)
+
+// Node - вершина графа вывода: выражение, правило и посылки, из которых оно получено.
+type Node struct {
+	Expression expression.Expression
+	Rule       string  // Правило вывода
+	Premises   []*Node // Посылки
+}
+
+// Store хранит вершины графа вывода. Для каждого выражения сохраняется первый найденный вывод.
+type Store interface {
+	// Add добавляет вершину, если выражение ещё не встречалось, и возвращает хранимую вершину.
+	Add(node *Node) *Node
+	// Get возвращает вершину по строковому представлению выражения.
+	Get(expr string) (*Node, bool)
+	// Len возвращает количество вершин.
+	Len() int
+	// Close освобождает ресурсы хранилища.
+	Close() error
+}
+
+// MemoryStore хранит граф вывода в памяти.
+type MemoryStore struct {
+	nodes map[string]*Node
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{nodes: make(map[string]*Node)}
+}
+
+func (m *MemoryStore) Add(node *Node) *Node {
+	key := node.Expression.String()
+	if existing, ok := m.nodes[key]; ok {
+		return existing
+	}
+	m.nodes[key] = node
+	return node
+}
+
+func (m *MemoryStore) Get(expr string) (*Node, bool) {
+	node, ok := m.nodes[expr]
+	return node, ok
+}
+
+func (m *MemoryStore) Len() int {
+	return len(m.nodes)
+}
+
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// FileStore хранит граф в памяти и дополнительно ведёт журнал вывода в файле
+// в формате "выражение правило посылка...".
+type FileStore struct {
+	*MemoryStore
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+
+	return &FileStore{
+		MemoryStore: NewMemoryStore(),
+		file:        file,
+		writer:      bufio.NewWriter(file),
+	}, nil
+}
+
+func (f *FileStore) Add(node *Node) *Node {
+	stored := f.MemoryStore.Add(node)
+	if stored != node {
+		return stored
+	}
+
+	parts := make([]string, 0, len(node.Premises)+2)
+	parts = append(parts, node.Expression.String(), node.Rule)
+	for _, premise := range node.Premises {
+		parts = append(parts, premise.Expression.String())
+	}
+	_, _ = fmt.Fprintln(f.writer, strings.Join(parts, " "))
+	return node
+}
+
+func (f *FileStore) Close() error {
+	if err := f.writer.Flush(); err != nil {
+		_ = f.file.Close()
+		return err
+	}
+	return f.file.Close()
+}
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+)
+
+// Justified - производное правило, заключение которого получается отделением посылок
+// по порядку от леммы по modus ponens. Поэтому шаг правила раскрывается в вывод леммы
+// и цепочку modus ponens.
+type Justified interface {
+	InferenceRule
+	Lemma() expression.Expression
+}
+
+// Derived - производное правило с леммой вида P1>(P2>(...>C)), где Pi - посылки правила.
+type Derived struct {
+	name  string
+	arity int
+	lemma expression.Expression
+}
+
+// NewDerived создаёт производное правило по записи леммы; arity первых антецедентов
+// леммы становятся посылками правила.
+func NewDerived(name string, arity int, lemma string) (Derived, error) {
+	expr, err := logicparser.Parse(lemma)
+	if err != nil {
+		return Derived{}, err
+	}
+	expr.Standardize()
+	expr.Normalize()
+	return Derived{name: name, arity: arity, lemma: *expr}, nil
+}
+
+func mustDerived(name string, arity int, lemma string) Derived {
+	rule, err := NewDerived(name, arity, lemma)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// Встроенные производные правила. В представлении выражений двойное отрицание
+// сокращается, поэтому DNI и DNE не меняют формулу, а контрапозиция совпадает с ISR
+// с точностью до переименования.
+var (
+	// HypotheticalSyllogism: A>B, B>C ⊢ A>C.
+	HypotheticalSyllogism = mustDerived("hs", 2, "(a>b)>((b>c)>(a>c))")
+	// Contraposition: A>B ⊢ !B>!A.
+	Contraposition = mustDerived("contra", 1, "(a>b)>(!b>!a)")
+	// DoubleNegationIntroduction: A ⊢ !!A.
+	DoubleNegationIntroduction = mustDerived("dni", 1, "a>!!a")
+	// DoubleNegationElimination: !!A ⊢ A.
+	DoubleNegationElimination = mustDerived("dne", 1, "!!a>a")
+	// ISR: !A>!B ⊢ B>A, шаг по лемме (!a>!b)>(b>a), которую решатель получает при запуске.
+	ISR = mustDerived("isr", 1, "(!a>!b)>(b>a)")
+)
+
+func (r Derived) Name() string { return r.name }
+
+func (r Derived) Arity() int { return r.arity }
+
+func (r Derived) Lemma() expression.Expression { return r.lemma }
+
+func (r Derived) Apply(premises ...expression.Expression) []expression.Expression {
+	if len(premises) != r.arity {
+		return nil
+	}
+
+	result := r.lemma
+	for _, premise := range premises {
+		result = *ApplyModusPonens(premise, result)
+		if result.Empty() {
+			return nil
+		}
+	}
+	return []expression.Expression{result}
+}
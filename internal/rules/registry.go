@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// InferenceRule - правило вывода: из Arity посылок, взятых по порядку, получает
+// ноль или больше наиболее общих заключений.
+type InferenceRule interface {
+	Name() string
+	Arity() int
+	Apply(premises ...expression.Expression) []expression.Expression
+}
+
+// ModusPonens: A, A>B ⊢ B.
+type ModusPonens struct{}
+
+func (ModusPonens) Name() string { return "mp" }
+
+func (ModusPonens) Arity() int { return 2 }
+
+func (ModusPonens) Apply(premises ...expression.Expression) []expression.Expression {
+	if len(premises) != 2 {
+		return nil
+	}
+
+	result := ApplyModusPonens(premises[0], premises[1])
+	if result.Empty() {
+		return nil
+	}
+	return []expression.Expression{*result}
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]InferenceRule{
+		ModusPonens{}.Name():              ModusPonens{},
+		HypotheticalSyllogism.Name():      HypotheticalSyllogism,
+		Contraposition.Name():             Contraposition,
+		DoubleNegationIntroduction.Name(): DoubleNegationIntroduction,
+		DoubleNegationElimination.Name():  DoubleNegationElimination,
+		ISR.Name():                        ISR,
+	}
+)
+
+// Register добавляет правило в реестр. Имена "axiom" и "hyp" заняты обозначениями
+// начальных формул вывода, "deduction" - шагом теоремы о дедукции.
+func Register(rule InferenceRule) error {
+	name := strings.ToLower(rule.Name())
+	if name == "axiom" || name == "hyp" || name == "deduction" {
+		return fmt.Errorf("rule name %q is reserved", rule.Name())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("rule %q is already registered", rule.Name())
+	}
+	registry[name] = rule
+	return nil
+}
+
+// Names возвращает имена зарегистрированных правил.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ByName возвращает зарегистрированное правило по имени.
+func ByName(name string) (InferenceRule, error) {
+	mu.RLock()
+	rule, ok := registry[strings.ToLower(name)]
+	mu.RUnlock()
+
+	if ok {
+		return rule, nil
+	}
+	return nil, fmt.Errorf("unknown inference rule %q (available: %s)", name, strings.Join(Names(), ", "))
+}
@@ -0,0 +1,54 @@
+package rules
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	weakening, err := NewDerived("weaken", 1, "a>(b>a)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Register(weakening); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(registry, weakening.Name())
+	})
+
+	rule, err := ByName("WEAKEN")
+	if err != nil {
+		t.Fatalf("ByName: %v", err)
+	}
+	if !slices.Contains(Names(), "weaken") {
+		t.Fatalf("Names = %v, want weaken among them", Names())
+	}
+	conclusions := rule.Apply(schema(t, "a>b"))
+	if want := schema(t, "A>(a>b)"); len(conclusions) != 1 || conclusions[0].String() != want.String() {
+		t.Fatalf("Apply = %v, want [%s]", conclusions, want.String())
+	}
+
+	tests := []struct {
+		name string
+		rule InferenceRule
+	}{
+		{"duplicate", weakening},
+		{"duplicate in another case", mustDerived("Weaken", 1, "a>(b>a)")},
+		{"built-in rule", ModusPonens{}},
+		{"axiom", mustDerived("axiom", 1, "a>a")},
+		{"hypothesis", mustDerived("hyp", 1, "a>a")},
+		{"deduction", mustDerived("deduction", 1, "a>a")},
+	}
+	for _, tt := range tests {
+		if err := Register(tt.rule); err == nil {
+			t.Errorf("%s: Register accepted rule %q", tt.name, tt.rule.Name())
+		}
+	}
+
+	if _, err = ByName("unknown"); err == nil {
+		t.Error("ByName returned an unregistered rule")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/tiendc/go-deepcopy"
 )
 
+// ApplyModusPonens возвращает наиболее общее заключение из lhs и rhs = A>B: B после
+// подстановки, унифицирующей lhs и A. Пустое выражение - правило неприменимо.
 func ApplyModusPonens(lhs, rhs expression.Expression) *expression.Expression {
 	if lhs.Empty() || rhs.Empty() {
 		return expression.NewExpression()
@@ -15,8 +17,15 @@ func ApplyModusPonens(lhs, rhs expression.Expression) *expression.Expression {
 		return expression.NewExpression()
 	}
 
+	// Переменные импликации целиком сдвигаются выше переменных lhs, а новые переменные
+	// унификации - выше переменных всей импликации, иначе они совпали бы с переменными
+	// заключения, которых нет в посылке
+	var result expression.Expression
+	_ = deepcopy.Copy(&result, &rhs)
+	result.ChangeVariables(lhs.MaxValue() + 1)
+
 	substitution := make(map[expression.Value]expression.Expression)
-	if !helper.GetUnification(lhs, *rhs.CopySubtree(rhs.Subtree(0).Left()), &substitution) {
+	if !helper.Unify(lhs, *result.CopySubtree(result.Subtree(0).Left()), result.MaxValue()+1, &substitution) {
 		return expression.NewExpression()
 	}
 
@@ -25,9 +34,6 @@ func ApplyModusPonens(lhs, rhs expression.Expression) *expression.Expression {
 		return ok
 	}
 
-	var result expression.Expression
-	_ = deepcopy.Copy(&result, &rhs)
-	result.ChangeVariables(lhs.MaxValue() + 1)
 	vars := result.Variables()
 
 	for _, value := range vars {
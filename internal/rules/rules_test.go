@@ -0,0 +1,49 @@
+package rules
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"testing"
+)
+
+func schema(t *testing.T, input string) expression.Expression {
+	t.Helper()
+	expr, err := logicparser.ParseSchema(input)
+	if err != nil {
+		t.Fatalf("parse %q: %v", input, err)
+	}
+	return *expr
+}
+
+func TestApplyModusPonens(t *testing.T) {
+	tests := []struct {
+		minor, major string
+		want         string // Пустая строка - правило неприменимо
+	}{
+		{"a", "a>b", "b"},
+		{"A>A", "A>(B>A)", "A>(B>B)"},
+		{"a>b", "(A>B)>(!B>!A)", "!b>!a"},
+		// Переменная C есть только в заключении, она не должна совпасть с новой
+		// переменной, которую унификация вводит для A и B
+		{"A>A", "(B>B)>(B>C)", "A>B"},
+		{"(a>b)>(a>(c>b))", "A>((B>C)>A)", "(A>B)>((a>b)>(a>(c>b)))"},
+		{"a", "b>a", ""},
+		{"a", "!a", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.minor+" "+tt.major, func(t *testing.T) {
+			got := ApplyModusPonens(schema(t, tt.minor), schema(t, tt.major))
+			if tt.want == "" {
+				if !got.Empty() {
+					t.Fatalf("ApplyModusPonens = %s, want not applicable", got.String())
+				}
+				return
+			}
+			want := schema(t, tt.want)
+			if got.String() != want.String() {
+				t.Fatalf("ApplyModusPonens = %s, want %s", got.String(), tt.want)
+			}
+		})
+	}
+}
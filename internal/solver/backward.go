@@ -0,0 +1,319 @@
+package solver
+
+import (
+	"context"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/helper"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"github.com/tiendc/go-deepcopy"
+	"slices"
+)
+
+// plan - найденный обратным поиском вывод цели: формула базы и доказательства посылок,
+// которые отделяются от неё по modus ponens по порядку.
+type plan struct {
+	base     int
+	premises []*plan
+}
+
+// backward - состояние обратного поиска от цели к аксиомам.
+type backward struct {
+	s      *Solver
+	ctx    context.Context
+	base   []expression.Expression
+	fresh  expression.Value // Следующее неиспользованное значение переменной
+	failed map[string]int   // Наибольшая глубина, на которой цель не удалось доказать
+	proved map[*plan]expression.Expression
+}
+
+// solveBackward ищет доказательство последней цели, двигаясь от неё к аксиомам: заключения
+// импликаций базы унифицируются с целью, а их посылки становятся подцелями. Глубина поиска
+// увеличивается постепенно до заданной, неудачи запоминаются. Найденный вывод проигрывается
+// по modus ponens и записывается в граф вывода так же, как при прямом поиске.
+func (s *Solver) solveBackward(ctx context.Context) {
+	b := &backward{
+		s:      s,
+		ctx:    ctx,
+		failed: make(map[string]int),
+		proved: make(map[*plan]expression.Expression),
+	}
+	// База нормализуется, поэтому формулы решателя копируются
+	_ = deepcopy.Copy(&b.base, &s.produced)
+	for i := range b.base {
+		b.base[i].Normalize()
+		b.fresh = max(b.fresh, b.base[i].MaxValue()+1)
+	}
+
+	goal := s.targets[len(s.targets)-1]
+	b.fresh = max(b.fresh, goal.MaxValue()+1)
+
+	accept := func(p *plan) bool {
+		result, ok := b.replay(p)
+		if !ok || !s.isTargetProvedBy(result) {
+			return false
+		}
+		s.axioms = append(s.axioms, result)
+		return true
+	}
+
+	for depth := 0; depth <= s.backwardDepth && !s.exhausted(ctx); depth++ {
+		if b.prove(goal, depth, accept) {
+			return
+		}
+	}
+}
+
+// prove перебирает выводы цели глубины не больше depth, пока accept не примет один из них.
+func (b *backward) prove(goal expression.Expression, depth int, accept func(*plan) bool) bool {
+	var key expression.Expression
+	_ = deepcopy.Copy(&key, &goal)
+	key.Normalize()
+	if d, ok := b.failed[key.String()]; ok && d >= depth {
+		return false
+	}
+
+	found := false
+	yield := func(p *plan) bool {
+		found = true
+		return accept(p)
+	}
+
+	// Цель - частный случай формулы базы
+	for i := range b.base {
+		if b.s.exhausted(b.ctx) || !b.s.countStep() {
+			return false
+		}
+
+		substitution := make(map[expression.Value]expression.Expression)
+		if helper.GetUnification(goal, b.base[i], &substitution) && yield(&plan{base: i}) {
+			return true
+		}
+	}
+
+	if depth > 0 {
+		for i := range b.base {
+			formula := b.base[i]
+			for peeled := 1; peeled <= premisesCount(formula); peeled++ {
+				if b.s.exhausted(b.ctx) {
+					return false
+				}
+
+				subgoals, ok := b.subgoals(goal, formula, peeled)
+				if !ok {
+					continue
+				}
+
+				proved := b.proveAll(subgoals, depth-1, nil, func(premises []*plan) bool {
+					return yield(&plan{base: i, premises: premises})
+				})
+				if proved {
+					return true
+				}
+			}
+		}
+	}
+
+	if !found && !b.s.exhausted(b.ctx) {
+		b.failed[key.String()] = depth
+	}
+	return false
+}
+
+// proveAll доказывает подцели по порядку. Конкретизация общих переменных, полученная при
+// доказательстве подцели, переносится на следующие подцели; при неудаче перебираются
+// другие доказательства предыдущих подцелей.
+func (b *backward) proveAll(subgoals []expression.Expression, depth int, premises []*plan, accept func([]*plan) bool) bool {
+	if len(subgoals) == 0 {
+		return accept(premises)
+	}
+
+	return b.prove(subgoals[0], depth, func(p *plan) bool {
+		result, ok := b.replay(p)
+		if !ok {
+			return false
+		}
+
+		substitution := make(map[expression.Value]expression.Expression)
+		if !helper.GetUnification(subgoals[0], result, &substitution) {
+			return false
+		}
+
+		// Переносим на следующие подцели только значения переменных доказанной подцели,
+		// остальные переменные этих значений делаем свежими
+		own := make(map[expression.Value]bool)
+		for _, val := range subgoals[0].Variables() {
+			own[val] = true
+		}
+		bindings := make(map[expression.Value]expression.Expression)
+		for val := range own {
+			if change, ok := resolve(val, substitution); ok {
+				bindings[val] = change
+			}
+		}
+
+		rest := make([]expression.Expression, 0, len(subgoals)-1)
+		for _, subgoal := range subgoals[1:] {
+			rest = append(rest, substitute(subgoal, bindings))
+		}
+		b.renameApart(rest, own)
+
+		return b.proveAll(rest, depth, append(slices.Clone(premises), p), accept)
+	})
+}
+
+// subgoals унифицирует цель с заключением формулы после отделения peeled посылок и
+// возвращает конкретизированные посылки.
+func (b *backward) subgoals(goal, formula expression.Expression, peeled int) ([]expression.Expression, bool) {
+	// Переменные заключения нумеруются первыми, чтобы GetUnification не сдвигал их
+	// относительно переменных посылок; посылки получают значения после запаса под новые
+	// переменные унификации.
+	consequent := formula.Subtree(0).Self()
+	antecedents := make([]uint, 0, peeled)
+	for range peeled {
+		antecedents = append(antecedents, formula.Subtree(consequent).Left())
+		consequent = formula.Subtree(consequent).Right()
+	}
+
+	conclusion := *formula.CopySubtree(consequent)
+	mapping := make(map[expression.Value]expression.Value)
+	next := goal.MaxValue() + 1
+	for _, val := range conclusion.Variables() {
+		if _, ok := mapping[val]; !ok {
+			mapping[val] = next
+			next++
+		}
+	}
+	next += expression.Value(goal.Size() + conclusion.Size() + 1)
+	for _, val := range formula.Variables() {
+		if _, ok := mapping[val]; !ok {
+			mapping[val] = next
+			next++
+		}
+	}
+
+	conclusion = rename(conclusion, mapping)
+	if !b.s.countStep() {
+		return nil, false
+	}
+
+	substitution := make(map[expression.Value]expression.Expression)
+	if !helper.GetUnification(goal, conclusion, &substitution) {
+		return nil, false
+	}
+
+	result := make([]expression.Expression, 0, peeled)
+	for _, idx := range antecedents {
+		antecedent := rename(*formula.CopySubtree(idx), mapping)
+		result = append(result, substitute(antecedent, substitution))
+	}
+	b.renameApart(result, nil)
+	return result, true
+}
+
+// replay проигрывает вывод по modus ponens, записывая шаги в граф вывода,
+// и возвращает наиболее общую доказанную формулу.
+func (b *backward) replay(p *plan) (expression.Expression, bool) {
+	if result, ok := b.proved[p]; ok {
+		return result, true
+	}
+
+	var result expression.Expression
+	_ = deepcopy.Copy(&result, &b.base[p.base])
+
+	for _, premise := range p.premises {
+		minor, ok := b.replay(premise)
+		if !ok {
+			return result, false
+		}
+
+		next := *rules.ApplyModusPonens(minor, result)
+		if next.Empty() {
+			return result, false
+		}
+		if b.s.record(next, "mp", minor, result) != nil {
+			return result, false
+		}
+		result = next
+	}
+
+	b.proved[p] = result
+	return result, true
+}
+
+// renameApart заменяет переменные выражений, кроме keep, на свежие.
+func (b *backward) renameApart(exprs []expression.Expression, keep map[expression.Value]bool) {
+	mapping := make(map[expression.Value]expression.Value)
+	for i := range exprs {
+		for _, val := range exprs[i].Variables() {
+			if _, ok := mapping[val]; ok || keep[val] {
+				continue
+			}
+			mapping[val] = b.fresh
+			b.fresh++
+		}
+	}
+
+	for i := range exprs {
+		exprs[i] = rename(exprs[i], mapping)
+	}
+}
+
+// rename переименовывает переменные выражения по отображению.
+func rename(expr expression.Expression, mapping map[expression.Value]expression.Value) expression.Expression {
+	nodes := make([]expression.Node, len(expr.Nodes))
+	copy(nodes, expr.Nodes)
+	for i := range nodes {
+		if nodes[i].Term.Type != expression.Variable {
+			continue
+		}
+		if val, ok := mapping[nodes[i].Term.Val]; ok {
+			nodes[i].Term.Val = val
+		}
+	}
+	return *expression.NewExpressionWithNodes(nodes)
+}
+
+// substitute применяет подстановку, полученную GetUnification, к выражению.
+func substitute(expr expression.Expression, substitution map[expression.Value]expression.Expression) expression.Expression {
+	var result expression.Expression
+	_ = deepcopy.Copy(&result, &expr)
+
+	for _, value := range result.Variables() {
+		if change, ok := resolve(value, substitution); ok {
+			result.Replace(value, change)
+		}
+	}
+	return result
+}
+
+// resolve возвращает значение переменной в подстановке, проходя цепочки переменных.
+func resolve(value expression.Value, substitution map[expression.Value]expression.Expression) (expression.Expression, bool) {
+	tmp, exists := substitution[value]
+	if !exists {
+		return tmp, false
+	}
+
+	var change expression.Expression
+	_ = deepcopy.Copy(&change, &tmp)
+	for change.Nodes[0].Term.Type == expression.Variable {
+		next, ok := substitution[change.Nodes[0].Term.Val]
+		if !ok || change.Nodes[0].Term.Val == value {
+			break
+		}
+		shouldNegate := change.Nodes[0].Term.Op == expression.Negation
+		_ = deepcopy.Copy(&change, &next)
+		if shouldNegate {
+			change.Negation(0)
+		}
+	}
+	return change, true
+}
+
+// premisesCount возвращает число посылок, которые можно последовательно отделить от формулы.
+func premisesCount(formula expression.Expression) int {
+	count := 0
+	for idx := formula.Subtree(0).Self(); formula.Nodes[idx].Term.Op == expression.Implication; count++ {
+		idx = formula.Subtree(idx).Right()
+	}
+	return count
+}
@@ -0,0 +1,59 @@
+package solver
+
+import (
+	"context"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"testing"
+	"time"
+)
+
+func TestBackwardChaining(t *testing.T) {
+	tests := []struct {
+		name       string
+		hypotheses []string
+		target     string
+		depth      int
+		want       Status
+	}{
+		{"premise", []string{"a"}, "a", 1, Proved},
+		{"one step", []string{"a", "a>b"}, "b", 1, Proved},
+		{"two steps", []string{"a", "a>b", "b>c"}, "c", 2, Proved},
+		{"depth cut-off", []string{"a", "a>b", "b>c"}, "c", 1, Unknown},
+		{"axiom instance", nil, "(!a>!b)>((!a>b)>a)", 1, Proved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hypotheses := make([]expression.Expression, 0, len(tt.hypotheses))
+			for _, h := range tt.hypotheses {
+				hypotheses = append(hypotheses, formula(t, h))
+			}
+
+			s := newSolver(t, tt.target, WithHypotheses(hypotheses...), WithBackwardChaining(tt.depth))
+			result := s.Solve()
+			if result.Status != tt.want {
+				t.Fatalf("status = %s, want %s (reason %q)", result.Status, tt.want, result.Reason)
+			}
+		})
+	}
+}
+
+func TestBackwardKeepsKnownFormulas(t *testing.T) {
+	s := newSolver(t, "c", WithHypotheses(formula(t, "a"), formula(t, "a>b"), formula(t, "b>c")), WithBackwardChaining(2))
+	s.Solve()
+
+	// База обратного поиска нормализуется, формулы решателя при этом меняться не должны
+	schema, err := logicparser.ParseSchema("B>A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.produced = append(s.produced, *schema)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	s.solveBackward(ctx)
+
+	if last := s.produced[len(s.produced)-1]; last.String() != "B>A" {
+		t.Fatalf("known formula B>A was changed to %s", last.String())
+	}
+}
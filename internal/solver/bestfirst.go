@@ -0,0 +1,117 @@
+package solver
+
+import (
+	"context"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/heuristic"
+	"github.com/spanwalla/logical-inference/internal/pkg/priorityqueue"
+	"github.com/spanwalla/logical-inference/internal/proof"
+)
+
+// candidate - формула в очереди поиска по первому наилучшему.
+type candidate struct {
+	heuristic.Candidate
+	score float64
+}
+
+// bestFirst ищет доказательство циклом "данной формулы": из очереди выбирается лучшая по
+// эвристике формула, становится известной и комбинируется со всеми известными ранее.
+// Если задано соотношение возраст/вес, каждая (ratio+1)-я формула выбирается как самая старая.
+func (s *Solver) bestFirst(ctx context.Context, maxLen int) {
+	byScore := priorityqueue.New(func(a, b *candidate) bool {
+		if a.score != b.score {
+			return a.score < b.score
+		}
+		return a.Age < b.Age
+	})
+	byAge := priorityqueue.New(func(a, b *candidate) bool {
+		return a.Age < b.Age
+	})
+
+	age := 0
+	push := func(expr expression.Expression) {
+		c := &candidate{Candidate: heuristic.Candidate{
+			Expression: expr,
+			Age:        age,
+			Depth:      s.derivationDepth(expr),
+			Targets:    s.targets,
+		}}
+		c.score = s.heuristic.Score(c.Candidate)
+		age++
+
+		byScore.Push(c)
+		if s.ageRatio > 0 {
+			byAge.Push(c)
+		}
+	}
+
+	for _, expr := range s.produced {
+		push(expr)
+	}
+
+	picked := make(map[int]bool)
+	pick := func(queue *priorityqueue.PriorityQueue[*candidate]) *candidate {
+		for !queue.Empty() {
+			c := *queue.Pop()
+			if !picked[c.Age] {
+				picked[c.Age] = true
+				return c
+			}
+		}
+		return nil
+	}
+
+	for picks := 0; !s.exhausted(ctx); picks++ {
+		var given *candidate
+		if s.ageRatio > 0 && picks%(s.ageRatio+1) == s.ageRatio {
+			given = pick(byAge)
+		} else {
+			given = pick(byScore)
+		}
+		if given == nil {
+			return
+		}
+
+		expr := given.Expression
+		if expr.Size() > maxLen || s.retired.Has(expr.String()) {
+			continue
+		}
+
+		expr.Normalize()
+		s.axioms = append(s.axioms, expr)
+		if s.isTargetProvedBy(expr) {
+			return
+		}
+
+		derived, proved := s.combine(ctx, maxLen)
+		if proved {
+			return
+		}
+		for _, d := range derived {
+			push(d)
+		}
+	}
+}
+
+// derivationDepth возвращает глубину вывода выражения по графу вывода.
+func (s *Solver) derivationDepth(expr expression.Expression) int {
+	node, ok := s.store.Get(expr.String())
+	if !ok {
+		return 0
+	}
+
+	var depth func(node *proof.Node) int
+	depth = func(node *proof.Node) int {
+		if d, ok := s.depths[node]; ok {
+			return d
+		}
+
+		d := 0
+		for _, premise := range node.Premises {
+			d = max(d, depth(premise)+1)
+		}
+		s.depths[node] = d
+		return d
+	}
+	return depth(node)
+}
@@ -0,0 +1,35 @@
+package solver
+
+import (
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/checker"
+	"github.com/spanwalla/logical-inference/internal/heuristic"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"testing"
+)
+
+func TestBestFirst(t *testing.T) {
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const target = "(a>b)>((b>c)>(a>c))"
+	for _, name := range heuristic.Names() {
+		for _, ratio := range []int{0, 3} {
+			h, err := heuristic.ByName(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := newSolver(t, target, WithHeuristic(h), WithAgeRatio(ratio))
+			result := s.Solve()
+			if result.Status != Proved {
+				t.Fatalf("%s, age ratio %d: status = %s, want proved (reason %q)", name, ratio, result.Status, result.Reason)
+			}
+			if err = checker.New(system.Axioms).Check(proof.Linearize(result.Proof), formula(t, target)); err != nil {
+				t.Fatalf("%s, age ratio %d: Check: %v", name, ratio, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package solver
+
+import (
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/helper"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"slices"
+)
+
+// eliminateHypotheses переводит вывод цели targets[targetIdx] из гипотез в вывод исходной цели,
+// исключая гипотезы в обратном порядке их появления.
+func (s *Solver) eliminateHypotheses(root *proof.Node, targetIdx int) (*proof.Node, error) {
+	if err := s.requireDeduction(); err != nil {
+		return nil, err
+	}
+
+	// Вывод промежуточной цели может опираться и на следующие гипотезы, поэтому сначала
+	// доводим его до последней цели
+	root, err := s.detachHypotheses(root, targetIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(s.hypotheses) - 1; i >= 0; i-- {
+		if root, err = proof.Deduction(root, s.hypotheses[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if !helper.IsInstance(root.Expression, s.targets[0]) {
+		return nil, fmt.Errorf("%s does not prove %s", root.Expression.String(), s.targets[0].String())
+	}
+	return root, nil
+}
+
+// dischargeHypotheses достраивает вывод цели targets[targetIdx] до вывода исходной цели шагами
+// deduction: шаг targets[i] = hypotheses[i]>targets[i+1] снимает гипотезу hypotheses[i].
+// Если вывод опирается на следующие гипотезы, он сначала доводится до последней цели.
+func (s *Solver) dischargeHypotheses(root *proof.Node, targetIdx int) (*proof.Node, error) {
+	last := targetIdx
+	if dependsOn(root, s.hypotheses[targetIdx:]) {
+		var err error
+		if root, err = s.detachHypotheses(root, targetIdx); err != nil {
+			return nil, err
+		}
+		last = len(s.hypotheses)
+	}
+
+	for i := last - 1; i >= 0; i-- {
+		root = &proof.Node{Expression: s.targets[i], Rule: "deduction", Premises: []*proof.Node{root}}
+	}
+	return root, nil
+}
+
+// detachHypotheses доводит вывод цели targets[targetIdx] до вывода последней цели, отделяя
+// по modus ponens следующие гипотезы.
+func (s *Solver) detachHypotheses(root *proof.Node, targetIdx int) (*proof.Node, error) {
+	for i := targetIdx; i < len(s.hypotheses); i++ {
+		hypothesis, ok := s.store.Get(s.hypotheses[i].String())
+		if !ok {
+			return nil, fmt.Errorf("no derivation recorded for hypothesis %s", s.hypotheses[i].String())
+		}
+
+		expr := *rules.ApplyModusPonens(hypothesis.Expression, root.Expression)
+		if expr.Empty() {
+			return nil, fmt.Errorf("modus ponens is not applicable to %s and %s",
+				hypothesis.Expression.String(), root.Expression.String())
+		}
+		root = &proof.Node{Expression: expr, Rule: "mp", Premises: []*proof.Node{hypothesis, root}}
+	}
+	return root, nil
+}
+
+// usesDeduction проверяет, нужна ли теорема о дедукции, чтобы довести вывод цели targets[targetIdx]
+// до исходной цели: выведена промежуточная цель или вывод опирается на гипотезы.
+func (s *Solver) usesDeduction(root *proof.Node, targetIdx int) bool {
+	return targetIdx > 0 || dependsOn(root, s.hypotheses)
+}
+
+// dependsOn проверяет, опирается ли вывод на одну из гипотез.
+func dependsOn(root *proof.Node, hypotheses []expression.Expression) bool {
+	keys := make(map[string]bool, len(hypotheses))
+	for i := range hypotheses {
+		keys[hypotheses[i].String()] = true
+	}
+
+	visited := make(map[*proof.Node]bool)
+	var visit func(node *proof.Node) bool
+	visit = func(node *proof.Node) bool {
+		if visited[node] {
+			return false
+		}
+		visited[node] = true
+		if node.Rule == "hyp" && keys[node.Expression.String()] {
+			return true
+		}
+		return slices.ContainsFunc(node.Premises, visit)
+	}
+	return visit(root)
+}
+
+// requireDeduction проверяет, что теорема о дедукции выражается через аксиомы системы.
+func (s *Solver) requireDeduction() error {
+	if err := proof.RequireDeductionAxioms(s.system.Axioms); err != nil {
+		return fmt.Errorf("axiom system %q: %w", s.system.Name, err)
+	}
+	return nil
+}
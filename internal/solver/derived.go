@@ -0,0 +1,80 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/lemmas"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"github.com/tiendc/go-deepcopy"
+)
+
+// expandDerived раскрывает шаги производных правил в выводе в цепочки modus ponens.
+func (s *Solver) expandDerived(ctx context.Context, root *proof.Node) (*proof.Node, error) {
+	return proof.Expand(root, func(rule rules.Justified) (*proof.Node, error) {
+		return s.lemmaProof(ctx, rule)
+	})
+}
+
+// lemmaProof возвращает вывод леммы производного правила из аксиом только по modus ponens.
+// Вывод берётся из библиотеки лемм; если его там нет, лемма доказывается отдельным решателем
+// как частный случай с константами, константы вывода заменяются переменными, и вывод
+// добавляется в библиотеку. Поиск вывода леммы ограничен контекстом и остатком бюджета шагов
+// решателя, а его шаги засчитываются решателю.
+func (s *Solver) lemmaProof(ctx context.Context, rule rules.Justified) (*proof.Node, error) {
+	if s.library == nil {
+		s.library = lemmas.New(s.system)
+	}
+
+	lemma := rule.Lemma()
+	if known, ok := s.library.Find(lemma); ok && byModusPonens(known.Proof) {
+		return known.Proof, nil
+	}
+
+	var target expression.Expression
+	_ = deepcopy.Copy(&target, &lemma)
+	target.MakeConst()
+
+	opts := []Option{WithDeductionElimination(), WithWorkers(s.workers), WithLibrary(s.library)}
+	if s.stepLimit > 0 {
+		if s.steps >= s.stepLimit {
+			return nil, fmt.Errorf("lemma %s of rule %s was not proved: step budget exhausted", lemma.String(), rule.Name())
+		}
+		opts = append(opts, WithStepLimit(s.stepLimit-s.steps))
+	}
+
+	sub, err := New(s.system, target, 0, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Close()
+
+	if err = sub.WriteInitialAxioms(); err != nil {
+		return nil, err
+	}
+	result := sub.SolveContext(ctx)
+	s.steps += sub.Steps()
+	if result.Status != Proved {
+		return nil, fmt.Errorf("lemma %s of rule %s was not proved: %s", lemma.String(), rule.Name(), result.Reason)
+	}
+	if !byModusPonens(result.Proof) {
+		return nil, fmt.Errorf("lemma %s of rule %s has no proof by modus ponens", lemma.String(), rule.Name())
+	}
+
+	proved, err := s.library.Add(proof.Generalize(result.Proof))
+	if err != nil {
+		return nil, err
+	}
+	return proved.Proof, nil
+}
+
+// byModusPonens проверяет, что вывод использует только аксиомы и modus ponens.
+func byModusPonens(root *proof.Node) bool {
+	for _, step := range proof.Linearize(root) {
+		if step.Rule != "axiom" && step.Rule != "mp" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package solver
+
+import (
+	"context"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"testing"
+)
+
+func TestLemmaProofBudget(t *testing.T) {
+	rule, err := rules.ByName("hs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	justified, ok := rule.(rules.Justified)
+	if !ok {
+		t.Fatal("rule hs has no lemma")
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		limit  uint64
+		spent  uint64 // Шаги, уже выполненные решателем
+		proved bool
+	}{
+		{"within the budget", context.Background(), 100000, 0, true},
+		{"budget spent by the search", context.Background(), 100000, 100000, false},
+		{"small remaining budget", context.Background(), 100000, 99990, false},
+		{"cancelled context", cancelled, 100000, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSolver(t, "a>a", WithStepLimit(tt.limit))
+			s.steps = tt.spent
+
+			_, err := s.lemmaProof(tt.ctx, justified)
+			if tt.proved && err != nil {
+				t.Fatalf("lemmaProof: %v", err)
+			}
+			if !tt.proved && err == nil {
+				t.Fatal("lemma was proved beyond the budget of the solver")
+			}
+			if s.steps > tt.limit {
+				t.Fatalf("%d steps spent, the limit is %d", s.steps, tt.limit)
+			}
+		})
+	}
+}
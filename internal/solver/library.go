@@ -0,0 +1,69 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spanwalla/logical-inference/internal/lemmas"
+	"github.com/spanwalla/logical-inference/internal/proof"
+)
+
+// errKnownFormula - цель выводится из аксиом и лемм за один шаг, новой леммой она не является.
+var errKnownFormula = errors.New("is an axiom or a known lemma")
+
+// remember запоминает доказанную цель и, если задано WithLibrary, добавляет её в библиотеку лемм.
+// Вывод из посылок леммой не является и не сохраняется.
+func (s *Solver) remember(ctx context.Context, root *proof.Node, targetIdx int) {
+	s.proved, s.provedIdx = root, targetIdx
+	if s.library == nil || !s.autoSave || len(s.premises) > 0 {
+		return
+	}
+
+	lemma, err := s.lemma(ctx, root, targetIdx)
+	if err == nil {
+		_, err = s.library.Add(lemma)
+	}
+	if err != nil && !errors.Is(err, errKnownFormula) {
+		s.builder.WriteString(fmt.Sprintf("lemma was not saved: %v\n", err))
+	}
+}
+
+// SaveLemma добавляет цель, доказанную последним поиском, в библиотеку лемм.
+func (s *Solver) SaveLemma() (lemmas.Lemma, error) {
+	if s.proved == nil {
+		return lemmas.Lemma{}, fmt.Errorf("no target has been proved")
+	}
+	if s.library == nil {
+		return lemmas.Lemma{}, fmt.Errorf("no lemma library")
+	}
+
+	lemma, err := s.lemma(context.Background(), s.proved, s.provedIdx)
+	if err != nil {
+		return lemmas.Lemma{}, err
+	}
+	return s.library.Add(lemma)
+}
+
+// lemma переводит доказанную цель в схему с выводом из аксиом. Вывод из посылок Γ леммой
+// не является, а вывод из гипотез теоремы о дедукции сначала переводится в вывод из аксиом.
+func (s *Solver) lemma(ctx context.Context, root *proof.Node, targetIdx int) (*proof.Node, error) {
+	if len(s.premises) > 0 {
+		return nil, fmt.Errorf("the proof depends on premises")
+	}
+
+	if s.usesDeduction(root, targetIdx) {
+		expanded, err := s.expandDerived(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		if root, err = s.eliminateHypotheses(expanded, targetIdx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Проверяется вывод из аксиом: вывод из гипотез мог закончиться гипотезой
+	if len(root.Premises) == 0 {
+		return nil, fmt.Errorf("%s %w", root.Expression.String(), errKnownFormula)
+	}
+	return proof.Generalize(root), nil
+}
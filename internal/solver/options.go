@@ -0,0 +1,132 @@
+package solver
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/heuristic"
+	"github.com/spanwalla/logical-inference/internal/lemmas"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"github.com/tiendc/go-deepcopy"
+	"slices"
+)
+
+// Option настраивает решатель.
+type Option func(*Solver)
+
+// WithStore задаёт хранилище графа вывода вместо хранилища в памяти.
+func WithStore(store proof.Store) Option {
+	return func(s *Solver) {
+		s.store = store
+	}
+}
+
+// WithStepLimit ограничивает поиск числом применений правил вывода. В отличие от
+// ограничения по времени, результат не зависит от загрузки машины.
+func WithStepLimit(steps uint64) Option {
+	return func(s *Solver) {
+		s.stepLimit = steps
+	}
+}
+
+// WithWorkers задаёт число обработчиков, между которыми распределяется применение
+// правил вывода. Результат поиска от числа обработчиков не зависит.
+func WithWorkers(workers int) Option {
+	return func(s *Solver) {
+		s.workers = max(workers, 1)
+	}
+}
+
+// WithHeuristic включает поиск по первому наилучшему вместо поиска поколениями:
+// следующей обрабатывается формула с наименьшей оценкой эвристики.
+func WithHeuristic(h heuristic.Heuristic) Option {
+	return func(s *Solver) {
+		s.heuristic = h
+	}
+}
+
+// WithAgeRatio задаёт соотношение возраст/вес при поиске по первому наилучшему: после
+// каждых ratio формул, выбранных по эвристике, выбирается самая старая. 0 - только эвристика.
+func WithAgeRatio(ratio int) Option {
+	return func(s *Solver) {
+		s.ageRatio = max(ratio, 0)
+	}
+}
+
+// WithSubsumption включает проверку поглощения: новые формулы, являющиеся частными случаями
+// уже полученных, отбрасываются, а полученные ранее частные случаи новой формулы исключаются из поиска.
+func WithSubsumption() Option {
+	return func(s *Solver) {
+		s.subsumption = true
+	}
+}
+
+// WithBackwardChaining включает обратный поиск от цели к аксиомам с ограничением глубины
+// дерева подцелей. Найденный вывод печатается так же, как при прямом поиске.
+func WithBackwardChaining(depth int) Option {
+	return func(s *Solver) {
+		s.backwardDepth = max(depth, 0)
+	}
+}
+
+// WithDeductionElimination исключает теорему о дедукции из найденного доказательства: вывод из
+// гипотез преобразуется в вывод исходной цели только из аксиом по modus ponens.
+func WithDeductionElimination() Option {
+	return func(s *Solver) {
+		s.pure = true
+	}
+}
+
+// WithPrinter задаёт печать формул в доказательстве. Сравнение формул при поиске от неё не зависит.
+func WithPrinter(printer expression.Printer) Option {
+	return func(s *Solver) {
+		s.printer = printer
+	}
+}
+
+// WithHypotheses задаёт посылки Γ: цель выводится из аксиом и посылок, которые
+// в доказательстве указываются как гипотезы (hyp).
+func WithHypotheses(hypotheses ...expression.Expression) Option {
+	return func(s *Solver) {
+		for i := range hypotheses {
+			var premise expression.Expression
+			_ = deepcopy.Copy(&premise, &hypotheses[i])
+			premise.Standardize()
+			s.premises = append(s.premises, premise)
+		}
+	}
+}
+
+// WithRules задаёт правила вывода прямого поиска вместо одного modus ponens. Поддерживаются
+// правила с одной и двумя посылками; обратный поиск всегда использует modus ponens.
+func WithRules(inferenceRules ...rules.InferenceRule) Option {
+	return func(s *Solver) {
+		if len(inferenceRules) > 0 {
+			s.rules = slices.Clone(inferenceRules)
+		}
+	}
+}
+
+// WithExpansion раскрывает шаги производных правил в найденном доказательстве в выводы
+// по modus ponens, чтобы доказательство опиралось только на аксиомы, гипотезы и modus ponens.
+func WithExpansion() Option {
+	return func(s *Solver) {
+		s.expand = true
+	}
+}
+
+// WithLibrary задаёт библиотеку лемм с проверенными выводами вместо начальных лемм системы.
+// Библиотека должна относиться к той же системе аксиом; леммы, доказанные для раскрытия
+// производных правил, и доказанная цель добавляются в неё.
+func WithLibrary(library *lemmas.Library) Option {
+	return func(s *Solver) {
+		s.library, s.autoSave = library, true
+	}
+}
+
+// WithLemmas задаёт библиотеку лемм, как WithLibrary, но доказанная цель добавляется
+// в неё только вызовом SaveLemma.
+func WithLemmas(library *lemmas.Library) Option {
+	return func(s *Solver) {
+		s.library, s.autoSave = library, false
+	}
+}
@@ -0,0 +1,71 @@
+package solver
+
+import (
+	"context"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/rules"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelThreshold - минимальное число пар, при котором имеет смысл запускать обработчики.
+const parallelThreshold = 32
+
+// conclusions - заключения бинарного правила для пары из известной и новейшей формулы.
+type conclusions struct {
+	forward  []expression.Expression // Известная формула - первая посылка
+	backward []expression.Expression // Новейшая формула - первая посылка
+}
+
+// applyRule применяет бинарное правило к новейшей формуле и каждой известной, распределяя
+// пары между обработчиками. Пока обработчики работают, общие данные только читаются, а
+// отбор дубликатов выполняет produce в исходном порядке пар, поэтому результат детерминирован.
+// Обратный порядок вычисляется только там, где он может понадобиться.
+func (s *Solver) applyRule(ctx context.Context, rule rules.InferenceRule, maxLen int) []conclusions {
+	last := len(s.axioms) - 1
+	size := len(s.axioms)
+	if s.stepLimit > 0 {
+		size = int(min(uint64(size), s.stepLimit-s.steps))
+	}
+	results := make([]conclusions, size)
+
+	apply := func(j int) {
+		results[j].forward = rule.Apply(s.axioms[j], s.axioms[last])
+		if j == last {
+			return
+		}
+
+		for _, expr := range results[j].forward {
+			if s.isGoodExpression(expr, maxLen) && !s.knownAxioms.Has(expr.String()) {
+				results[j].backward = rule.Apply(s.axioms[last], s.axioms[j])
+				return
+			}
+		}
+	}
+
+	workers := min(s.workers, size)
+	if workers <= 1 || size < parallelThreshold {
+		for j := range results {
+			apply(j)
+		}
+		return results
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				j := int(next.Add(1)) - 1
+				if j >= size {
+					return
+				}
+				apply(j)
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
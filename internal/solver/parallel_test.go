@@ -0,0 +1,44 @@
+package solver
+
+import (
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"slices"
+	"testing"
+)
+
+func TestParallelProofsAreIdentical(t *testing.T) {
+	targets := []string{
+		"(a>b)>((b>c)>(a>c))",
+		"a>(b>(a*b))",
+		"(a>b)>((c>a)>(c>b))",
+	}
+
+	for _, target := range targets {
+		t.Run(target, func(t *testing.T) {
+			var proofs [][]proof.Step
+			var steps []uint64
+			for _, workers := range []int{1, 4} {
+				s := newSolver(t, target, WithWorkers(workers))
+				result := s.Solve()
+				if result.Status != Proved {
+					t.Fatalf("%d workers: status = %s, want proved (reason %q)", workers, result.Status, result.Reason)
+				}
+				// Обработчики запускаются, только если пар не меньше parallelThreshold
+				if len(s.axioms) < parallelThreshold {
+					t.Fatalf("%d known formulas, the parallel path needs %d", len(s.axioms), parallelThreshold)
+				}
+				proofs = append(proofs, proof.Linearize(result.Proof))
+				steps = append(steps, s.Steps())
+			}
+
+			if steps[0] != steps[1] {
+				t.Fatalf("%d steps with one worker, %d with four", steps[0], steps[1])
+			}
+			if !slices.EqualFunc(proofs[0], proofs[1], func(a, b proof.Step) bool {
+				return a.Expression.String() == b.Expression.String() && a.Rule == b.Rule && slices.Equal(a.Premises, b.Premises)
+			}) {
+				t.Fatal("proofs found with one and four workers differ")
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package solver
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/helper"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"time"
+)
+
+// Proof - итог последнего поиска в структурированном виде для программ: те же шаги,
+// что печатает ThoughtChain, но с кодированием в JSON. Формулы печатаются принтером решателя.
+type Proof struct {
+	Status       Status            `json:"status"`
+	Target       string            `json:"target"`                // Цель в исходных связках
+	Proved       string            `json:"proved,omitempty"`      // Выведенная цель в базисе импликации и отрицания
+	Hypotheses   []string          `json:"hypotheses,omitempty"`  // Посылки
+	Assumptions  []string          `json:"assumptions,omitempty"` // Гипотезы теоремы о дедукции, снятые шагами deduction
+	Steps        []ProofStep       `json:"steps,omitempty"`
+	Substitution map[string]string `json:"substitution,omitempty"` // Подстановка, переводящая последний шаг в цель
+	Countermodel map[string]bool   `json:"countermodel,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+	Statistics   Statistics        `json:"statistics"`
+}
+
+// ProofStep - шаг доказательства. Шаги и посылки нумеруются с 1, как в ThoughtChain.
+type ProofStep struct {
+	Index    int    `json:"index"`
+	Formula  string `json:"formula"`
+	Rule     string `json:"rule"`
+	Premises []int  `json:"premises"`
+}
+
+// Statistics - показатели поиска.
+type Statistics struct {
+	Elapsed   time.Duration `json:"elapsed_ns"`
+	Steps     uint64        `json:"steps"` // Число применений правил вывода
+	Known     int           `json:"known"` // Число известных решателю формул
+	Workers   int           `json:"workers"`
+	TimeLimit uint64        `json:"time_limit_ms"`
+	StepLimit uint64        `json:"step_limit"`
+}
+
+// Proof возвращает итог последнего вызова Solve или SolveContext.
+func (s *Solver) Proof() Proof {
+	result := Proof{
+		Status: s.result.Status,
+		Target: s.printer.Print(&s.original),
+		Reason: s.result.Reason,
+		Statistics: Statistics{
+			Elapsed:   s.elapsed,
+			Steps:     s.steps,
+			Known:     len(s.known),
+			Workers:   s.workers,
+			TimeLimit: s.timeLimit,
+			StepLimit: s.stepLimit,
+		},
+	}
+
+	if s.result.Countermodel != nil {
+		result.Countermodel = make(map[string]bool, len(s.result.Countermodel))
+		for val, value := range s.result.Countermodel {
+			atom := expression.NewExpressionWithTerm(expression.Term{Type: expression.Constant, Op: expression.Nop, Val: val})
+			result.Countermodel[s.printer.Print(atom)] = value
+		}
+	}
+
+	if s.result.Status != Proved {
+		return result
+	}
+
+	result.Proved = s.printer.Print(&s.targets[0])
+	for i := range s.result.Hypotheses {
+		result.Hypotheses = append(result.Hypotheses, s.printer.Print(&s.result.Hypotheses[i]))
+	}
+	for i := range s.result.Assumptions {
+		result.Assumptions = append(result.Assumptions, s.printer.Print(&s.result.Assumptions[i]))
+	}
+
+	for i, step := range proof.Linearize(s.result.Proof) {
+		premises := make([]int, len(step.Premises))
+		for k, premise := range step.Premises {
+			premises[k] = premise + 1
+		}
+		result.Steps = append(result.Steps, ProofStep{
+			Index:    i + 1,
+			Formula:  s.printer.Print(&step.Expression),
+			Rule:     step.Rule,
+			Premises: premises,
+		})
+	}
+
+	substitution := make(map[expression.Value]expression.Expression)
+	helper.GetUnification(s.targets[0], s.result.Proof.Expression, &substitution)
+	if len(substitution) > 0 {
+		result.Substitution = make(map[string]string, len(substitution))
+		for key, value := range substitution {
+			variable := expression.NewExpressionWithTerm(expression.Term{Type: expression.Variable, Op: expression.Nop, Val: key})
+			result.Substitution[s.printer.Print(variable)] = s.printer.Print(&value)
+		}
+	}
+	return result
+}
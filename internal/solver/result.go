@@ -0,0 +1,45 @@
+package solver
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/proof"
+)
+
+// Status - итог поиска доказательства.
+type Status int
+
+const (
+	Unknown Status = iota // Доказательство не найдено в пределах бюджета поиска
+	Proved                // Цель выведена
+	Refuted               // Цель не следует из посылок, найден опровергающий набор
+)
+
+var statusNames = map[Status]string{
+	Unknown: "unknown",
+	Proved:  "proved",
+	Refuted: "refuted",
+}
+
+// String возвращает имя статуса; неизвестное значение считается статусом Unknown.
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return statusNames[Unknown]
+}
+
+// MarshalText кодирует статус его именем, например в JSON.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Result - результат поиска. Заполняются только поля, соответствующие статусу.
+type Result struct {
+	Status       Status
+	Proof        *proof.Node             // Корень проверенного вывода исходной цели (Proved)
+	Target       expression.Expression   // Исходная цель; Proof выводит её запись в базисе импликации и отрицания (Proved)
+	Hypotheses   []expression.Expression // Посылки, на которые может опираться Proof (Proved)
+	Assumptions  []expression.Expression // Гипотезы теоремы о дедукции, снятые в Proof шагами deduction (Proved)
+	Countermodel expression.Assignment   // Набор, на котором посылки истинны, а цель ложна (Refuted)
+	Reason       string                  // Почему поиск остановлен без доказательства (Unknown)
+}
@@ -0,0 +1,31 @@
+package solver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStatusString(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   string
+	}{
+		{Unknown, "unknown"},
+		{Proved, "proved"},
+		{Refuted, "refuted"},
+		{Status(42), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.String(); got != tt.want {
+			t.Errorf("Status(%d).String() = %q, want %q", int(tt.status), got, tt.want)
+		}
+		data, err := json.Marshal(tt.status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(data); got != `"`+tt.want+`"` {
+			t.Errorf("json.Marshal(Status(%d)) = %s, want %q", int(tt.status), got, tt.want)
+		}
+	}
+}
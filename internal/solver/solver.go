@@ -1,129 +1,218 @@
 package solver
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/scylladb/go-set/strset"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/checker"
 	"github.com/spanwalla/logical-inference/internal/expression"
 	"github.com/spanwalla/logical-inference/internal/helper"
-	"github.com/spanwalla/logical-inference/internal/logicparser"
-	"github.com/spanwalla/logical-inference/internal/pkg/alphabet"
+	"github.com/spanwalla/logical-inference/internal/heuristic"
+	"github.com/spanwalla/logical-inference/internal/lemmas"
+	"github.com/spanwalla/logical-inference/internal/proof"
 	"github.com/spanwalla/logical-inference/internal/rules"
 	"github.com/tiendc/go-deepcopy"
-	"math"
-	"os"
+	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
-type Node struct {
-	Expression   string   // Строковое представление выражения
-	Rule         string   // Правило вывода
-	Dependencies []string // Зависимости
-}
-
-func msSinceEpoch() uint64 {
-	return uint64(time.Now().UnixNano() / int64(time.Millisecond))
-}
-
 type Solver struct {
 	knownAxioms strset.Set
+	system      axioms.System
 	axioms      []expression.Expression
+	lemmas      []expression.Expression
 	produced    []expression.Expression
+	known       []expression.Expression // Формулы графа вывода в порядке добавления
 	targets     []expression.Expression
+	original    expression.Expression // Цель в исходных связках
 
-	timeLimit uint64
+	timeLimit uint64 // Ограничение по времени в миллисекундах, 0 - без ограничения
+	stepLimit uint64 // Ограничение по числу применений правил вывода, 0 - без ограничения
+	steps     uint64 // Число выполненных применений правил вывода
+	workers   int    // Число обработчиков при параллельном применении правил
 
-	builder    strings.Builder
-	outputFile *os.File
-	fileWriter *bufio.Writer
-}
+	heuristic heuristic.Heuristic // Эвристика поиска по первому наилучшему, nil - поиск поколениями
+	ageRatio  int                 // Каждая (ageRatio+1)-я выбранная формула - самая старая
+	depths    map[*proof.Node]int
 
-func New(axioms []expression.Expression, target expression.Expression, timeLimit uint64) (*Solver, error) {
-	if timeLimit < 1 {
-		timeLimit = 60000
-	}
+	subsumption bool                    // Включена ли проверка поглощения
+	kept        []expression.Expression // Формулы, участвующие в проверке поглощения
+	retired     strset.Set              // Формулы, исключённые обратным поглощением
 
-	if len(axioms) < 3 {
-		return nil, fmt.Errorf("not enough axioms to solve (3 required)")
-	}
+	backwardDepth int // Наибольшая глубина обратного поиска, 0 - прямой поиск
 
-	file, err := os.Create("conclusions.txt")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create file: %w", err)
+	conjunctions    uint // Наибольшее число конъюнкций в полученной формуле
+	conjunctionRoot bool // Допустимы ли формулы с конъюнкцией в корне
+
+	premises   []expression.Expression // Посылки Γ, заданные при создании решателя
+	hypotheses []expression.Expression // Гипотезы, перенесённые из цели по теореме о дедукции
+	pure       bool                    // Исключать ли теорему о дедукции из доказательства
+
+	printer expression.Printer    // Печать формул в доказательстве
+	rules   []rules.InferenceRule // Правила вывода, применяемые при прямом поиске
+
+	expand   bool            // Раскрывать ли шаги производных правил в modus ponens
+	library  *lemmas.Library // Леммы с проверенными выводами
+	autoSave bool            // Добавлять ли доказанную цель в библиотеку
+
+	proved    *proof.Node // Вывод цели, найденный последним поиском
+	provedIdx int         // Номер доказанной цели в targets
+
+	result  Result        // Итог последнего поиска
+	elapsed time.Duration // Время последнего поиска
+
+	builder strings.Builder
+	store   proof.Store
+}
+
+// New создаёт решатель. Ограничение по времени задаётся в миллисекундах; если оно равно нулю
+// и не задано ограничение по шагам (WithStepLimit), используется ограничение в 60 секунд.
+func New(system axioms.System, target expression.Expression, timeLimit uint64, opts ...Option) (*Solver, error) {
+	if len(system.Axioms) < 1 {
+		return nil, fmt.Errorf("axiom system %q has no axioms", system.Name)
 	}
 
-	var targetCopy expression.Expression
+	var targetCopy, original expression.Expression
 	_ = deepcopy.Copy(&targetCopy, &target)
+	_ = deepcopy.Copy(&original, &target)
+	targetCopy.Standardize()
+
+	var axiomsCopy []expression.Expression
+	_ = deepcopy.Copy(&axiomsCopy, &system.Axioms)
 
-	return &Solver{
+	s := &Solver{
 		knownAxioms: *strset.New(),
-		axioms:      axioms,
+		system:      system,
+		axioms:      axiomsCopy,
+		lemmas:      []expression.Expression{},
 		produced:    []expression.Expression{},
 		targets:     []expression.Expression{targetCopy},
+		original:    original,
 		timeLimit:   timeLimit,
 		builder:     strings.Builder{},
-		outputFile:  file,
-		fileWriter:  bufio.NewWriter(file),
-	}, nil
+		store:       proof.NewMemoryStore(),
+		workers:     runtime.GOMAXPROCS(0),
+		depths:      make(map[*proof.Node]int),
+		retired:     *strset.New(),
+		rules:       []rules.InferenceRule{rules.ModusPonens{}},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	for _, rule := range s.rules {
+		if arity := rule.Arity(); arity < 1 || arity > 2 {
+			return nil, fmt.Errorf("inference rule %q has unsupported arity %d", rule.Name(), arity)
+		}
+	}
+
+	if s.timeLimit < 1 && s.stepLimit < 1 {
+		s.timeLimit = 60000
+	}
+	return s, nil
 }
 
-// Close закрывает поток вывода, необходимо использовать всегда.
+// Close освобождает хранилище графа вывода, необходимо использовать всегда.
 func (s *Solver) Close() {
-	if s.outputFile != nil {
-		err := s.outputFile.Close()
-		if err != nil {
-			fmt.Println("failed to close output file:", err)
+	if err := s.store.Close(); err != nil {
+		fmt.Println("failed to close proof store:", err)
+	}
+}
+
+// record добавляет в граф вывода выражение, полученное по правилу из посылок. Вершина
+// не добавляется, если какой-либо посылки нет в графе вывода.
+func (s *Solver) record(expr expression.Expression, rule string, premises ...expression.Expression) error {
+	node := &proof.Node{Rule: rule, Premises: make([]*proof.Node, 0, len(premises))}
+	_ = deepcopy.Copy(&node.Expression, &expr)
+
+	for i := range premises {
+		premise, ok := s.store.Get(premises[i].String())
+		if !ok {
+			return fmt.Errorf("premise %s of %s is not recorded", premises[i].String(), expr.String())
 		}
+		node.Premises = append(node.Premises, premise)
 	}
+	if s.store.Add(node) == node {
+		s.known = append(s.known, node.Expression)
+	}
+	return nil
 }
 
+// WriteInitialAxioms записывает аксиомы и выводы лемм из библиотеки, леммы становятся начальными
+// формулами поиска. Если библиотека не задана, она строится из начальных лемм системы.
 func (s *Solver) WriteInitialAxioms() error {
-	axioms := []expression.Expression{
-		*logicparser.NewExpressionWithString("a>(b>a)"),
-		*logicparser.NewExpressionWithString("(a>(b>c))>((a>b)>(a>c))"),
-		*logicparser.NewExpressionWithString("(!a>!b)>((!a>b)>a)"),
-	}
-
-	axioms = append(axioms, *rules.ApplyModusPonens(axioms[0], axioms[0]))
-	axioms = append(axioms, *rules.ApplyModusPonens(axioms[1], axioms[0]))
-	axioms = append(axioms, *rules.ApplyModusPonens(axioms[3], axioms[1]))
-	axioms = append(axioms, *rules.ApplyModusPonens(axioms[4], axioms[1]))
-	axioms = append(axioms, *rules.ApplyModusPonens(axioms[2], axioms[5]))
-	axioms = append(axioms, *rules.ApplyModusPonens(axioms[6], axioms[6]))
-	axioms = append(axioms, *rules.ApplyModusPonens(axioms[7], axioms[8]))
-	axioms = append(axioms, *rules.ApplyModusPonens(axioms[3], axioms[9]))
-
-	trios := [][3]int{
-		{3, 0, 0},
-		{4, 1, 0},
-		{5, 3, 1},
-		{6, 4, 1},
-		{7, 2, 5},
-		{8, 6, 5},
-		{9, 7, 8},
-		{10, 3, 9},
-	}
-
-	for _, t := range trios {
-		if _, err := fmt.Fprintf(s.fileWriter, "%s mp %s %s\n", axioms[t[0]].String(), axioms[t[1]].String(),
-			axioms[t[2]].String()); err != nil {
+	for i := range s.system.Axioms {
+		if err := s.record(s.system.Axioms[i], "axiom"); err != nil {
+			return err
+		}
+	}
+
+	if s.library == nil {
+		library, err := lemmas.Bootstrap(s.system)
+		if err != nil {
+			return err
+		}
+		s.library = library
+	}
+	if err := s.requireSameSystem(s.library.System()); err != nil {
+		return err
+	}
+
+	for _, lemma := range s.library.Lemmas() {
+		if err := s.recordProof(lemma.Proof); err != nil {
+			return err
+		}
+		s.lemmas = append(s.lemmas, lemma.Expression)
+	}
+	return nil
+}
+
+// recordProof записывает в граф вывода все формулы вывода, посылки раньше заключений.
+func (s *Solver) recordProof(root *proof.Node) error {
+	steps := proof.Linearize(root)
+	for _, step := range steps {
+		premises := make([]expression.Expression, 0, len(step.Premises))
+		for _, idx := range step.Premises {
+			premises = append(premises, steps[idx].Expression)
+		}
+		if err := s.record(step.Expression, step.Rule, premises...); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// Проверка, доказано ли целевое выражение
+// requireSameSystem проверяет, что леммы выведены в системе аксиом решателя.
+func (s *Solver) requireSameSystem(system axioms.System) error {
+	same := len(system.Axioms) == len(s.system.Axioms)
+	for i := 0; same && i < len(system.Axioms); i++ {
+		same = system.Axioms[i].String() == s.system.Axioms[i].String()
+	}
+	if !same {
+		return fmt.Errorf("lemma library was built for axiom system %q, not %q", system.Name, s.system.Name)
+	}
+	return nil
+}
+
+// Проверка, доказано ли целевое выражение: цель должна быть частным случаем выражения.
+// Выведенная схема доказывает любой свой частный случай: та же подстановка в её вывод даёт
+// вывод частного случая, а гипотезы - константы и подстановкой не меняются. Сравнение
+// с точностью до переименования (IsEqual) пропускало цели вроде (a>b)>(c>(a>b)), которые
+// являются частными случаями уже известной схемы A>(B>A), но не совпадают с ней по форме.
 func (s *Solver) isTargetProvedBy(expr expression.Expression) bool {
 	if expr.Empty() {
 		return false
 	}
 
 	for _, target := range s.targets {
-		if helper.IsEqual(target, expr) {
+		if helper.IsInstance(expr, target) {
 			return true
 		}
 	}
@@ -132,8 +221,20 @@ func (s *Solver) isTargetProvedBy(expr expression.Expression) bool {
 
 func (s *Solver) isGoodExpression(expr expression.Expression, maxLen int) bool {
 	return !(expr.Size() > maxLen || expr.Empty() ||
-		expr.Nodes[0].Term.Op == expression.Conjunction ||
-		expr.Operations(expression.Conjunction) > 1)
+		(expr.Nodes[0].Term.Op == expression.Conjunction && !s.conjunctionRoot) ||
+		expr.Operations(expression.Conjunction) > s.conjunctions)
+}
+
+// limitConjunctions разрешает формулы с конъюнкциями настолько, насколько они встречаются в целях
+// и посылках: конъюнкция в корне допустима, только если она есть в корне одной из них.
+func (s *Solver) limitConjunctions() {
+	s.conjunctions = 1
+	for _, target := range append(slices.Clone(s.targets), s.premises...) {
+		s.conjunctions = max(s.conjunctions, target.Operations(expression.Conjunction))
+		if target.Nodes[0].Term.Op == expression.Conjunction {
+			s.conjunctionRoot = true
+		}
+	}
 }
 
 func (s *Solver) deductionTheoremDecomposition(expr expression.Expression) bool {
@@ -147,92 +248,155 @@ func (s *Solver) deductionTheoremDecomposition(expr expression.Expression) bool
 
 	// Γ ⊢ A → B <=> Γ U {A} ⊢ B
 	s.axioms = append(s.axioms, *expr.CopySubtree(expr.Subtree(0).Left()))
+	s.hypotheses = append(s.hypotheses, *expr.CopySubtree(expr.Subtree(0).Left()))
 	s.targets = append(s.targets, *expr.CopySubtree(expr.Subtree(0).Right()))
 	return true
 }
 
-func (s *Solver) produce(maxLen int) {
-	if len(s.produced) == 0 {
-		return
+// exhausted проверяет, исчерпан ли бюджет поиска.
+func (s *Solver) exhausted(ctx context.Context) bool {
+	return ctx.Err() != nil || (s.stepLimit > 0 && s.steps >= s.stepLimit)
+}
+
+// countStep учитывает очередное применение правила вывода. Возвращает false, если бюджет исчерпан.
+func (s *Solver) countStep() bool {
+	if s.stepLimit > 0 && s.steps >= s.stepLimit {
+		return false
 	}
+	s.steps++
+	return true
+}
 
-	newlyProduced := make([]expression.Expression, 0, len(s.produced)*2)
-	var expr expression.Expression
+// combine применяет включённые правила вывода к новейшей известной формуле и всем остальным известным.
+// Возвращает новые формулы и признак того, что одна из них доказывает цель.
+func (s *Solver) combine(ctx context.Context, maxLen int) ([]expression.Expression, bool) {
+	derived := make([]expression.Expression, 0)
+	last := len(s.axioms) - 1
 
-	for i := range s.produced {
-		if msSinceEpoch() > s.timeLimit {
-			break
+	accept := func(rule rules.InferenceRule, expr expression.Expression, premises ...int) bool {
+		if !s.isGoodExpression(expr, maxLen) || s.knownAxioms.Has(expr.String()) {
+			return false
 		}
 
-		if s.produced[i].Size() > maxLen {
-			continue
+		var tmp expression.Expression
+		_ = deepcopy.Copy(&tmp, &expr)
+
+		sources := make([]expression.Expression, 0, len(premises))
+		for _, idx := range premises {
+			sources = append(sources, s.axioms[idx])
+		}
+		if s.record(tmp, rule.Name(), sources...) != nil {
+			return false
 		}
+		s.knownAxioms.Add(tmp.String())
 
-		s.produced[i].Normalize()
-		tmp := s.produced[i]
-		var copiedTmp expression.Expression
-		_ = deepcopy.Copy(&copiedTmp, &tmp)
-		s.axioms = append(s.axioms, copiedTmp)
+		// Поглощённое выражение может доказывать цель, поэтому оно проверяется, но не сохраняется
+		if !s.keep(tmp) {
+			return s.isTargetProvedBy(tmp)
+		}
 
-		if s.isTargetProvedBy(copiedTmp) {
-			return
+		derived = append(derived, tmp)
+		return true
+	}
+
+	proves := func(expr expression.Expression) bool {
+		if !s.isTargetProvedBy(expr) {
+			return false
 		}
 
-		for j := 0; j < len(s.axioms); j++ {
-			expr = *rules.ApplyModusPonens(s.axioms[j], s.axioms[len(s.axioms)-1])
+		var axiom expression.Expression
+		_ = deepcopy.Copy(&axiom, &expr)
+		s.axioms = append(s.axioms, axiom)
+		return true
+	}
 
-			if !s.isGoodExpression(expr, maxLen) || s.knownAxioms.Has(expr.String()) {
+	// acceptAll принимает заключения правила; accepted - принято ли хотя бы одно
+	acceptAll := func(rule rules.InferenceRule, exprs []expression.Expression, premises ...int) (accepted, proved bool) {
+		for _, expr := range exprs {
+			if !accept(rule, expr, premises...) {
 				continue
 			}
+			accepted = true
+			if proves(expr) {
+				return true, true
+			}
+		}
+		return accepted, false
+	}
 
-			_ = deepcopy.Copy(&tmp, &expr)
-			newlyProduced = append(newlyProduced, tmp)
-			s.knownAxioms.Add(tmp.String())
+	for _, rule := range s.rules {
+		if rule.Arity() == 1 {
+			if !s.countStep() {
+				break
+			}
+			if _, proved := acceptAll(rule, rule.Apply(s.axioms[last]), last); proved {
+				return derived, true
+			}
+			continue
+		}
 
-			_, err := fmt.Fprintf(s.fileWriter, "%s mp %s %s\n", tmp.String(), s.axioms[j].String(), s.axioms[len(s.axioms)-1].String())
-			if err != nil {
-				fmt.Println(err)
-				return
+		for j, result := range s.applyRule(ctx, rule, maxLen) {
+			if !s.countStep() {
+				break
 			}
 
-			if s.isTargetProvedBy(tmp) {
-				var axiom expression.Expression
-				_ = deepcopy.Copy(&axiom, &tmp)
-				s.axioms = append(s.axioms, axiom)
-				return
+			accepted, proved := acceptAll(rule, result.forward, j, last)
+			if proved {
+				return derived, true
+			}
+			if !accepted {
+				continue
 			}
 
-			if len(s.axioms) == j+1 {
+			if last == j || !s.countStep() {
 				break
 			}
 
 			// Обратный порядок
-			expr = *rules.ApplyModusPonens(s.axioms[len(s.axioms)-1], s.axioms[j])
-
-			if !s.isGoodExpression(expr, maxLen) || s.knownAxioms.Has(expr.String()) {
-				continue
+			if _, proved := acceptAll(rule, result.backward, last, j); proved {
+				return derived, true
 			}
+		}
+	}
 
-			_ = deepcopy.Copy(&tmp, &expr)
-			newlyProduced = append(newlyProduced, tmp)
-			s.knownAxioms.Add(tmp.String())
+	s.dropRetired()
+	return derived, false
+}
 
-			_, err = fmt.Fprintf(s.fileWriter, "%s mp %s %s\n", tmp.String(), s.axioms[len(s.axioms)-1].String(), s.axioms[j].String())
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
+func (s *Solver) produce(ctx context.Context, maxLen int) {
+	if len(s.produced) == 0 {
+		return
+	}
 
-			if s.isTargetProvedBy(tmp) {
-				var axiom expression.Expression
-				_ = deepcopy.Copy(&axiom, &tmp)
-				s.axioms = append(s.axioms, axiom)
-				return
-			}
+	newlyProduced := make([]expression.Expression, 0, len(s.produced)*2)
+
+	for i := range s.produced {
+		if s.exhausted(ctx) {
+			break
+		}
+
+		if s.produced[i].Size() > maxLen || s.retired.Has(s.produced[i].String()) {
+			continue
+		}
+
+		s.produced[i].Normalize()
+		tmp := s.produced[i]
+		var copiedTmp expression.Expression
+		_ = deepcopy.Copy(&copiedTmp, &tmp)
+		s.axioms = append(s.axioms, copiedTmp)
+
+		if s.isTargetProvedBy(copiedTmp) {
+			return
+		}
+
+		derived, proved := s.combine(ctx, maxLen)
+		newlyProduced = append(newlyProduced, derived...)
+		if proved {
+			return
 		}
 	}
 
-	if msSinceEpoch() > s.timeLimit {
+	if s.exhausted(ctx) {
 		return
 	}
 
@@ -244,17 +408,78 @@ func (s *Solver) produce(maxLen int) {
 	s.produced = newlyProduced
 }
 
-func (s *Solver) Solve() {
+// Solve ищет доказательство с ограничениями, заданными при создании решателя.
+func (s *Solver) Solve() Result {
+	return s.SolveContext(context.Background())
+}
+
+// SolveContext ищет доказательство до его нахождения, исчерпания бюджета или отмены контекста.
+// Невыводимая цель опровергается набором значений без поиска.
+func (s *Solver) SolveContext(ctx context.Context) Result {
+	start := time.Now()
+	s.result = s.solve(ctx)
+	s.elapsed = time.Since(start)
+	return s.result
+}
+
+func (s *Solver) solve(ctx context.Context) Result {
 	s.builder.Reset()
 	limit := 20
 
-	for s.deductionTheoremDecomposition(s.targets[len(s.targets)-1]) {
+	if s.library != nil {
+		if missing := s.library.Missing(); len(missing) > 0 {
+			names := make([]string, 0, len(missing))
+			for i := range missing {
+				names = append(names, s.printer.Print(&missing[i]))
+			}
+			s.builder.WriteString(fmt.Sprintf("bootstrap lemmas were not derived: %s\n", strings.Join(names, ", ")))
+		}
+	}
+
+	// Невыводимое выражение отсекаем сразу, не тратя время на поиск: Γ ⊢ φ возможно,
+	// только если φ истинна на всех наборах, где истинны посылки
+	entailment := s.targets[0]
+	for i := len(s.premises) - 1; i >= 0; i-- {
+		entailment = expression.Construct(s.premises[i], expression.Implication, entailment)
+	}
+	ok, counterexample, err := entailment.IsTautology()
+	if err != nil {
+		// Таблица истинности слишком велика, выводимость проверит сам поиск
+		s.builder.WriteString(fmt.Sprintf("tautology check was skipped: %v\n", err))
+		ok = true
+	}
+	if !ok {
+		if len(s.premises) == 0 {
+			s.builder.WriteString(fmt.Sprintf("%s is not a tautology\n", s.printer.Print(&s.original)))
+		} else {
+			s.builder.WriteString(fmt.Sprintf("%s does not follow from the hypotheses\n", s.printer.Print(&s.original)))
+		}
+		s.builder.WriteString(fmt.Sprintf("counterexample: %s\n", entailment.Describe(counterexample)))
+		return Result{Status: Refuted, Countermodel: counterexample}
+	}
+
+	for i := range s.premises {
+		var premise expression.Expression
+		_ = deepcopy.Copy(&premise, &s.premises[i])
+		s.axioms = append(s.axioms, premise)
+	}
+
+	// Теорема о дедукции применима, только если её шаги выражаются через аксиомы системы;
+	// иначе цель ищется целиком
+	deduction := s.requireDeduction()
+	if deduction != nil && !s.pure {
+		s.builder.WriteString(fmt.Sprintf("deduction theorem is not used: %v\n", deduction))
+	}
+	for deduction == nil && s.deductionTheoremDecomposition(s.targets[len(s.targets)-1]) {
 		prev := s.targets[len(s.targets)-2]
 		curr := s.targets[len(s.targets)-1]
 		axiom := s.axioms[len(s.axioms)-1]
 
-		s.builder.WriteString(fmt.Sprintf("deduction theorem: Γ ⊢ %s <=> Γ U {%s} ⊢ %s\n", prev.String(), axiom.String(), curr.String()))
+		if !s.pure {
+			s.builder.WriteString(fmt.Sprintf("deduction theorem: Γ ⊢ %s <=> Γ U {%s} ⊢ %s\n", s.printer.Print(&prev), s.printer.Print(&axiom), s.printer.Print(&curr)))
+		}
 	}
+	s.limitConjunctions()
 
 	for i := range s.axioms {
 		s.axioms[i].Normalize()
@@ -264,30 +489,46 @@ func (s *Solver) Solve() {
 		_ = deepcopy.Copy(&copiedTmp, &tmp)
 		s.produced = append(s.produced, copiedTmp)
 
-		_, err := fmt.Fprintf(s.fileWriter, "%s axiom\n", s.axioms[i].String())
-		if err != nil {
-			fmt.Println(err)
-			return
+		rule := "hyp"
+		if i < len(s.system.Axioms) {
+			rule = "axiom"
+		}
+		if err := s.record(s.axioms[i], rule); err != nil {
+			reason := fmt.Sprintf("initial formula was not recorded: %v", err)
+			s.builder.WriteString(reason + "\n")
+			return Result{Status: Unknown, Reason: reason}
 		}
 	}
 
-	// isr rule
-	s.produced = append(s.produced, *logicparser.NewExpressionWithString("(!a>!b)>(b>a)"))
+	// Начальные леммы системы
+	for i := range s.lemmas {
+		var lemma expression.Expression
+		_ = deepcopy.Copy(&lemma, &s.lemmas[i])
+		s.produced = append(s.produced, lemma)
+	}
 	s.axioms = make([]expression.Expression, 0)
 	s.knownAxioms = *strset.New()
+	for i := range s.produced {
+		s.keep(s.produced[i])
+	}
 
-	// calculate the stopping criterion
-	now := msSinceEpoch()
-	if now > math.MaxUint64-s.timeLimit {
-		s.timeLimit = math.MaxUint64
-	} else {
-		s.timeLimit = now + s.timeLimit
+	if s.timeLimit > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(s.timeLimit)*time.Millisecond)
+		defer cancel()
 	}
 
-	for msSinceEpoch() < s.timeLimit {
-		s.produce(limit)
-		if s.isTargetProvedBy(s.axioms[len(s.axioms)-1]) {
-			break
+	switch {
+	case s.backwardDepth > 0:
+		s.solveBackward(ctx)
+	case s.heuristic != nil:
+		s.bestFirst(ctx, limit)
+	default:
+		for !s.exhausted(ctx) && len(s.produced) > 0 {
+			s.produce(ctx, limit)
+			if len(s.axioms) > 0 && s.isTargetProvedBy(s.axioms[len(s.axioms)-1]) {
+				break
+			}
 		}
 	}
 
@@ -300,153 +541,159 @@ func (s *Solver) Solve() {
 	}
 
 	if !found {
-		s.builder.WriteString("No proof was found in the time allotted\n")
-		return
+		var reason string
+		switch {
+		case errors.Is(ctx.Err(), context.Canceled):
+			reason = "search was cancelled"
+			s.builder.WriteString("Proof search was cancelled\n")
+		case s.stepLimit > 0 && s.steps >= s.stepLimit:
+			reason = "step budget exhausted"
+			s.builder.WriteString("No proof was found within the step budget\n")
+		case ctx.Err() != nil:
+			reason = "time limit exceeded"
+			s.builder.WriteString("No proof was found in the time allotted\n")
+		default:
+			reason = "search space exhausted"
+			s.builder.WriteString("No proof was found: search space exhausted\n")
+		}
+		return Result{Status: Unknown, Reason: reason}
 	}
 
-	proof := *expression.NewExpression()
-	targetProved := *expression.NewExpression()
+	proved := *expression.NewExpression()
+	targetIdx := 0
 
 	for _, axiom := range s.axioms {
-		if !proof.Empty() {
+		if !proved.Empty() {
 			break
 		}
 
-		for _, target := range s.targets {
-			if helper.IsEqual(target, axiom) {
-				_ = deepcopy.Copy(&proof, &axiom)
-				_ = deepcopy.Copy(&targetProved, &target)
+		for i, target := range s.targets {
+			if helper.IsInstance(axiom, target) {
+				_ = deepcopy.Copy(&proved, &axiom)
+				targetIdx = i
 				break
 			}
 		}
 	}
 
-	if err := s.fileWriter.Flush(); err != nil {
-		fmt.Println("Error flushing writer:", err)
-		return
-	}
-	s.buildThoughtChain(proof, targetProved)
-}
-
-func (s *Solver) buildThoughtChain(proof expression.Expression, provedTarget expression.Expression) {
-	if _, err := s.outputFile.Seek(0, 0); err != nil {
-		fmt.Println("Error seeking file:", err)
-		return
+	root, ok := s.store.Get(proved.String())
+	if !ok {
+		reason := "no derivation recorded for " + proved.String()
+		s.builder.WriteString(fmt.Sprintf("proof was not built: %s\n", reason))
+		return Result{Status: Unknown, Reason: reason}
 	}
 
-	conclusions := make(map[string]Node)
-	indices := make(map[string]int)
-	chain := make(map[int]Node)
-	processedProofs := *strset.New()
-	nextIndex := 1
-
-	scanner := bufio.NewScanner(s.outputFile)
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Fields(line)
-		if len(parts) < 2 {
-			continue // Skip if the line doesn't contain at least an expression and a rule
-		}
-
-		expr := parts[0]
-		rule := parts[1]
-
-		// Skip if the expression already exists in the map
-		if _, exists := conclusions[expr]; exists {
-			continue
+	// Вывод без теоремы о дедукции строится только из шагов modus ponens
+	viaDeduction := s.usesDeduction(root, targetIdx)
+	if s.expand || s.pure && viaDeduction {
+		expanded, err := s.expandDerived(ctx, root)
+		if err != nil {
+			s.builder.WriteString(fmt.Sprintf("derived rules were not expanded: %v\n", err))
+		} else {
+			root = expanded
 		}
+	}
 
-		conclusions[expr] = Node{
-			Expression:   expr,
-			Rule:         rule,
-			Dependencies: parts[2:],
+	if s.pure && viaDeduction {
+		// Запрошен вывод только из аксиом, вывод из гипотез его не заменяет
+		pure, err := s.eliminateHypotheses(root, targetIdx)
+		if err != nil {
+			reason := fmt.Sprintf("deduction theorem was not eliminated: %v", err)
+			s.builder.WriteString(reason + "\n")
+			return Result{Status: Unknown, Reason: reason}
 		}
+		root, targetIdx = pure, 0
 	}
 
-	var treeLevels [][]string
-	treeLevels = append(treeLevels, []string{proof.String()})
-
-	for len(treeLevels) > 0 && len(treeLevels[len(treeLevels)-1]) > 0 {
-		level := make([]string, 0)
-		for _, expr := range treeLevels[len(treeLevels)-1] {
-			node := conclusions[expr]
-			if processedProofs.Has(node.Expression) {
-				continue
-			}
-
-			if node.Rule == "axiom" {
-				if _, exists := indices[node.Expression]; exists {
-					continue
-				}
-
-				chain[nextIndex] = node
-				indices[node.Expression] = nextIndex
-				nextIndex++
-			}
+	// Гипотезы теоремы о дедукции снимаются явными шагами, и вывод заканчивается исходной целью
+	discharged, err := s.dischargeHypotheses(root, targetIdx)
+	if err != nil {
+		reason := fmt.Sprintf("deduction theorem was not applied: %v", err)
+		s.builder.WriteString(reason + "\n")
+		return Result{Status: Unknown, Reason: reason}
+	}
 
-			for _, dep := range node.Dependencies {
-				level = append(level, dep)
-			}
+	if err = s.buildThoughtChain(discharged); err != nil {
+		return Result{Status: Unknown, Reason: err.Error()}
+	}
 
-			processedProofs.Add(node.Expression)
-		}
-		treeLevels = append(treeLevels, level)
+	// Снятые гипотезы - антецеденты целей, выведенных шагами deduction
+	assumptions := 0
+	for node := discharged; node.Rule == "deduction"; node = node.Premises[0] {
+		assumptions++
 	}
 
-	// Reverse the treeLevels slice
-	for i, j := 0, len(treeLevels)-1; i < j; i, j = i+1, j-1 {
-		treeLevels[i], treeLevels[j] = treeLevels[j], treeLevels[i]
+	s.remember(ctx, root, targetIdx)
+	return Result{
+		Status:      Proved,
+		Proof:       discharged,
+		Target:      s.original,
+		Hypotheses:  slices.Clone(s.premises),
+		Assumptions: slices.Clone(s.hypotheses[:assumptions]),
 	}
+}
 
-	for _, level := range treeLevels {
-		for _, expr := range level {
-			if _, exists := indices[expr]; exists {
-				continue
-			}
+// buildThoughtChain проверяет вывод исходной цели и печатает его. Отвергнутый проверкой вывод
+// не печатается.
+func (s *Solver) buildThoughtChain(root *proof.Node) error {
+	proved, provedTarget := root.Expression, s.targets[0]
+	steps := proof.Linearize(root)
 
-			chain[nextIndex] = conclusions[expr]
-			indices[expr] = nextIndex
-			nextIndex++
-		}
+	// Доказательство показывается только после независимой проверки
+	if err := checker.New(s.system.Axioms, s.premises...).Check(steps, s.original); err != nil {
+		s.builder.WriteString(fmt.Sprintf("Proof rejected by checker: %v\n", err))
+		return fmt.Errorf("proof rejected by checker: %w", err)
 	}
 
-	for i := 1; i < nextIndex; i++ {
-		node := chain[i]
-		s.builder.WriteString(fmt.Sprintf("%d. ", i))
+	for i, step := range steps {
+		s.builder.WriteString(fmt.Sprintf("%d. ", i+1))
 
-		if node.Rule == "axiom" {
-			s.builder.WriteString("axiom")
+		if len(step.Premises) == 0 {
+			s.builder.WriteString(step.Rule)
 		} else {
-			s.builder.WriteString(fmt.Sprintf("%s(", node.Rule))
-			for k := 0; k < len(node.Dependencies); k++ {
-				s.builder.WriteString(strconv.Itoa(indices[node.Dependencies[k]]))
+			s.builder.WriteString(fmt.Sprintf("%s(", step.Rule))
+			for k, premise := range step.Premises {
+				s.builder.WriteString(strconv.Itoa(premise + 1))
 
-				if len(node.Dependencies) != k+1 {
+				if len(step.Premises) != k+1 {
 					s.builder.WriteString(",")
 				}
 			}
 			s.builder.WriteString(")")
 		}
-		s.builder.WriteString(fmt.Sprintf(": %s\n", node.Expression))
+		s.builder.WriteString(fmt.Sprintf(": %s\n", s.printer.Print(&step.Expression)))
 	}
 
 	// Change variables if required
 	substitution := make(map[expression.Value]expression.Expression)
-	helper.GetUnification(provedTarget, proof, &substitution)
-	if len(substitution) == 0 {
-		return
+	helper.GetUnification(provedTarget, proved, &substitution)
+	if len(substitution) > 0 {
+		s.builder.WriteString(fmt.Sprintf("change variables: %s\n", s.printer.Print(&proved)))
+		for key, value := range substitution {
+			variable := expression.NewExpressionWithTerm(expression.Term{Type: expression.Variable, Op: expression.Nop, Val: key})
+			s.builder.WriteString(fmt.Sprintf("%s \u2192 %s\n", s.printer.Print(variable), s.printer.Print(&value)))
+		}
+		s.builder.WriteString(fmt.Sprintf("proved: %s\n", s.printer.Print(&provedTarget)))
 	}
 
-	s.builder.WriteString(fmt.Sprintf("change variables: %s\n", proof.String()))
-	for key, value := range substitution {
-		letter, err := alphabet.GetLetter(int(key), true)
-		if err != nil {
-			fmt.Println("Error in substitution, letter set to 'X':", err)
-			letter = 'X'
-		}
-		s.builder.WriteString(fmt.Sprintf("%c \u2192 %s\n", letter, value.String()))
+	// Цель доказывалась в базисе импликации и отрицания, возвращаемся к исходным связкам
+	if s.original.String() != s.targets[0].String() {
+		s.builder.WriteString(fmt.Sprintf("by definition: %s\n", s.printer.Print(&s.original)))
 	}
-	s.builder.WriteString(fmt.Sprintf("proved: %s\n", provedTarget.String()))
+	return nil
+}
+
+// Steps возвращает число применений правил вывода, выполненных при поиске.
+func (s *Solver) Steps() uint64 {
+	return s.steps
+}
+
+// Known возвращает формулы, известные решателю после поиска, в порядке их получения: аксиомы
+// и посылки, леммы с выводами и выведенные формулы. Набор не зависит от стратегии поиска.
+func (s *Solver) Known() []expression.Expression {
+	var known []expression.Expression
+	_ = deepcopy.Copy(&known, &s.known)
+	return known
 }
 
 func (s *Solver) ThoughtChain() string {
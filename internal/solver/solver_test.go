@@ -0,0 +1,385 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/spanwalla/logical-inference/internal/axioms"
+	"github.com/spanwalla/logical-inference/internal/checker"
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/heuristic"
+	"github.com/spanwalla/logical-inference/internal/lemmas"
+	"github.com/spanwalla/logical-inference/internal/logicparser"
+	"github.com/spanwalla/logical-inference/internal/proof"
+	"slices"
+	"testing"
+)
+
+// formula разбирает формулу с константами, как её вводит пользователь.
+func formula(t *testing.T, input string) expression.Expression {
+	t.Helper()
+	expr, err := logicparser.Parse(input)
+	if err != nil {
+		t.Fatalf("parse %q: %v", input, err)
+	}
+	expr.MakeConst()
+	return *expr
+}
+
+func newSolver(t *testing.T, target string, opts ...Option) *Solver {
+	t.Helper()
+	return newSolverIn(t, "mendelson", target, opts...)
+}
+
+func newSolverIn(t *testing.T, preset, target string, opts ...Option) *Solver {
+	t.Helper()
+	system, err := axioms.Preset(preset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newSolverFor(t, system, target, opts...)
+}
+
+// testStepLimit ограничивает поиск в тестах вместо времени, чтобы результат не зависел
+// от скорости машины; параметр WithStepLimit теста его заменяет.
+const testStepLimit = 100000
+
+func newSolverFor(t *testing.T, system axioms.System, target string, opts ...Option) *Solver {
+	t.Helper()
+	opts = append([]Option{WithStepLimit(testStepLimit)}, opts...)
+	s, err := New(system, formula(t, target), 0, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+
+	if err = s.WriteInitialAxioms(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestRecordRequiresPremises(t *testing.T) {
+	s := newSolver(t, "a>a")
+	a, b, c := formula(t, "a"), formula(t, "a>b"), formula(t, "b")
+
+	if err := s.record(c, "mp", a, b); err == nil {
+		t.Fatal("record accepted a step whose premises are not recorded")
+	}
+	if _, ok := s.store.Get(c.String()); ok {
+		t.Fatal("step with missing premises was added to the proof graph")
+	}
+
+	_ = s.record(a, "hyp")
+	_ = s.record(b, "hyp")
+	if err := s.record(c, "mp", a, b); err != nil {
+		t.Fatal(err)
+	}
+	node, _ := s.store.Get(c.String())
+	if len(node.Premises) != 2 {
+		t.Fatalf("recorded %d premises, want 2", len(node.Premises))
+	}
+}
+
+func TestTargetProvedByInstance(t *testing.T) {
+	tests := []struct {
+		derived string
+		proves  bool
+	}{
+		{"A>(B>A)", true},
+		{"(A>B)>(C>(A>B))", true},
+		{"A>(B>B)", false},
+		{"(a>b)>(c>(a>b))", true},
+		{"(a>b)>(c>(a>c))", false},
+	}
+
+	s := newSolver(t, "(a>b)>(c>(a>b))")
+	for _, tt := range tests {
+		expr, err := logicparser.ParseSchema(tt.derived)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.isTargetProvedBy(*expr); got != tt.proves {
+			t.Errorf("isTargetProvedBy(%s) = %v, want %v", tt.derived, got, tt.proves)
+		}
+	}
+}
+
+func TestAxiomInstanceProvesTarget(t *testing.T) {
+	s := newSolver(t, "(a>b)>(c>(a>b))", WithStepLimit(1))
+	result := s.Solve()
+	if result.Status != Proved {
+		t.Fatalf("status = %s, reason %q", result.Status, result.Reason)
+	}
+	if result.Proof.Rule != "axiom" {
+		t.Fatalf("proof ends with rule %s, want an axiom instance", result.Proof.Rule)
+	}
+}
+
+func TestSearchBudget(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		target string
+		ctx    context.Context
+		opts   []Option
+		status Status
+		reason string
+	}{
+		{"cancelled", "(a>b)>((b>c)>(a>c))", cancelled, nil, Unknown, "search was cancelled"},
+		{"cancelled best-first", "(a>b)>((b>c)>(a>c))", cancelled,
+			[]Option{WithHeuristic(heuristic.Size{})}, Unknown, "search was cancelled"},
+		{"step budget", "(a>b)>((b>c)>(a>c))", context.Background(),
+			[]Option{WithStepLimit(10)}, Unknown, "step budget exhausted"},
+		{"step budget best-first", "(a>b)>((b>c)>(a>c))", context.Background(),
+			[]Option{WithStepLimit(2), WithHeuristic(heuristic.Size{})}, Unknown, "step budget exhausted"},
+		// Невыводимая цель опровергается до поиска
+		{"refuted without search", "a>b", cancelled, nil, Refuted, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSolver(t, tt.target, tt.opts...)
+			result := s.SolveContext(tt.ctx)
+			if result.Status != tt.status || result.Reason != tt.reason {
+				t.Fatalf("result = %s %q, want %s %q", result.Status, result.Reason, tt.status, tt.reason)
+			}
+			if s.stepLimit > 0 && s.Steps() > s.stepLimit {
+				t.Fatalf("%d steps spent, the limit is %d", s.Steps(), s.stepLimit)
+			}
+		})
+	}
+}
+
+func TestPureProof(t *testing.T) {
+	tests := []struct {
+		system string
+		target string
+		steps  uint64 // 0 - ограничение testStepLimit
+		want   Status
+	}{
+		{"mendelson", "a>a", 0, Proved},
+		{"mendelson", "(a>b)>((b>c)>(a>c))", 0, Proved},
+		// В системе Лукасевича нет схем A1 и A2, теорема о дедукции не применяется
+		{"lukasiewicz", "a>(b>a)", 5000, Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system+" "+tt.target, func(t *testing.T) {
+			opts := []Option{WithDeductionElimination()}
+			if tt.steps > 0 {
+				opts = append(opts, WithStepLimit(tt.steps))
+			}
+			result := newSolverIn(t, tt.system, tt.target, opts...).Solve()
+			if result.Status != tt.want {
+				t.Fatalf("status = %s, want %s (reason %q)", result.Status, tt.want, result.Reason)
+			}
+			if result.Status != Proved {
+				return
+			}
+			for _, step := range proof.Linearize(result.Proof) {
+				if step.Rule == "hyp" {
+					t.Fatalf("pure proof uses hypothesis %s", step.Expression.String())
+				}
+			}
+		})
+	}
+}
+
+func TestUsesDeduction(t *testing.T) {
+	s := newSolver(t, "a>(b>a)")
+	s.Solve()
+
+	axiom := &proof.Node{Expression: s.targets[0], Rule: "axiom"}
+	hypothesis := &proof.Node{Expression: s.hypotheses[0], Rule: "hyp"}
+	tests := []struct {
+		name      string
+		root      *proof.Node
+		targetIdx int
+		want      bool
+	}{
+		{"original target from axioms", axiom, 0, false},
+		{"original target from a hypothesis", &proof.Node{Expression: s.targets[0], Rule: "mp",
+			Premises: []*proof.Node{hypothesis, axiom}}, 0, true},
+		{"intermediate target", hypothesis, 2, true},
+	}
+	for _, tt := range tests {
+		if got := s.usesDeduction(tt.root, tt.targetIdx); got != tt.want {
+			t.Errorf("%s: usesDeduction = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeductionRequiresSchemas(t *testing.T) {
+	weak, err := axioms.New("weak", []string{"a>a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := newSolverFor(t, weak, "a>(b>a)", WithStepLimit(1000)).Solve()
+	if result.Status == Proved {
+		t.Fatalf("a>(b>a) proved in a system with the only axiom a>a")
+	}
+}
+
+func TestKnown(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"generations", nil},
+		{"best-first", []Option{WithHeuristic(heuristic.Size{})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSolver(t, "(a>b)>((b>c)>(a>c))", tt.opts...)
+			result := s.Solve()
+			if result.Status != Proved {
+				t.Fatalf("status = %s, want proved (reason %q)", result.Status, result.Reason)
+			}
+
+			known := make(map[string]bool)
+			for _, expr := range s.Known() {
+				known[expr.String()] = true
+			}
+			// Известны все формулы графа вывода, а не только начальные или последнее поколение
+			if len(known) != s.store.Len() {
+				t.Fatalf("%d known formulas, the proof graph has %d", len(known), s.store.Len())
+			}
+		})
+	}
+}
+
+func TestSaveLemma(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string // Пустая строка - цель не является новой леммой
+	}{
+		// Вывод из гипотез a и b заканчивается гипотезой, но после исключения теоремы
+		// о дедукции это вывод из аксиом
+		{"a>(b>b)", "A>(B>B)"},
+		{"(a>b)>((b>c)>(a>c))", "(A>B)>((B>C)>(A>C))"},
+		{"a>(b>a)", ""},
+	}
+
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			library, err := lemmas.Bootstrap(system)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := newSolver(t, tt.target, WithLemmas(library))
+			if result := s.Solve(); result.Status != Proved {
+				t.Fatalf("status = %s, want proved", result.Status)
+			}
+
+			lemma, err := s.SaveLemma()
+			if tt.want == "" {
+				if !errors.Is(err, errKnownFormula) {
+					t.Fatalf("SaveLemma error = %v, want %v", err, errKnownFormula)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SaveLemma: %v", err)
+			}
+			if got := lemma.Expression.String(); got != tt.want {
+				t.Fatalf("lemma = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProofDocumentRoundTrip(t *testing.T) {
+	tests := []struct {
+		target   string
+		premises []string
+		opts     []Option
+	}{
+		{target: "a>a"},
+		{target: "a>(b>(a*b))"},
+		{target: "!a|a"},
+		{target: "(a>b)>((b>c)>(a>c))"},
+		{target: "a>(b>b)", opts: []Option{WithDeductionElimination()}},
+		{target: "b>a", premises: []string{"a"}},
+		{target: "a", premises: []string{"!a>!b", "!b>!c", "c"}},
+	}
+
+	system, err := axioms.Preset("mendelson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			opts := slices.Clone(tt.opts)
+			for _, premise := range tt.premises {
+				opts = append(opts, WithHypotheses(formula(t, premise)))
+			}
+
+			result := newSolver(t, tt.target, opts...).Solve()
+			if result.Status != Proved {
+				t.Fatalf("status = %s, want proved", result.Status)
+			}
+			if got, want := result.Target.String(), formula(t, tt.target); got != want.String() {
+				t.Fatalf("result target = %s, want %s", got, want.String())
+			}
+			if len(result.Hypotheses) != len(tt.premises) {
+				t.Fatalf("result hypotheses = %d, want the %d premises", len(result.Hypotheses), len(tt.premises))
+			}
+
+			var buffer bytes.Buffer
+			doc := proof.Document{Hypotheses: result.Hypotheses, Target: result.Target, Steps: proof.Linearize(result.Proof)}
+			if err := proof.WriteDocument(&buffer, doc); err != nil {
+				t.Fatal(err)
+			}
+			read, err := proof.ReadDocument(&buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = checker.New(system.Axioms, read.Hypotheses...).Check(read.Steps, read.Target); err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+		})
+	}
+}
+
+func TestProofReport(t *testing.T) {
+	tests := []struct {
+		target      string
+		assumptions []string
+		last        string // Правило последнего шага
+	}{
+		{"a>(b>b)", []string{"a", "b"}, "deduction"},
+		{"a>(b>a)", nil, "axiom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			s := newSolver(t, tt.target)
+			s.Solve()
+
+			report := s.Proof()
+			if report.Status != Proved || report.Target != tt.target || len(report.Hypotheses) != 0 {
+				t.Fatalf("report = %s %s with hypotheses %v, want proved %s without hypotheses",
+					report.Status, report.Target, report.Hypotheses, tt.target)
+			}
+			if !slices.Equal(report.Assumptions, tt.assumptions) {
+				t.Fatalf("assumptions = %v, want %v", report.Assumptions, tt.assumptions)
+			}
+			last := report.Steps[len(report.Steps)-1]
+			if last.Rule != tt.last {
+				t.Fatalf("last step = %s %s, want rule %s", last.Formula, last.Rule, tt.last)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package solver
+
+import (
+	"github.com/spanwalla/logical-inference/internal/expression"
+	"github.com/spanwalla/logical-inference/internal/helper"
+)
+
+// isSubsumed проверяет, является ли выражение частным случаем уже сохранённой формулы
+// (прямое поглощение). Такое выражение ничего не добавляет к поиску.
+func (s *Solver) isSubsumed(expr expression.Expression) bool {
+	for i := range s.kept {
+		if s.kept[i].Size() > expr.Size() || s.retired.Has(s.kept[i].String()) {
+			continue
+		}
+
+		if helper.IsInstance(s.kept[i], expr) {
+			return true
+		}
+	}
+	return false
+}
+
+// retireSubsumedBy исключает из поиска сохранённые формулы, которые являются частными
+// случаями нового выражения (обратное поглощение). Вывод исключённых формул остаётся в графе.
+func (s *Solver) retireSubsumedBy(expr expression.Expression) {
+	kept := s.kept[:0]
+	for i := range s.kept {
+		if s.kept[i].Size() >= expr.Size() && helper.IsInstance(expr, s.kept[i]) {
+			s.retired.Add(s.kept[i].String())
+			continue
+		}
+		kept = append(kept, s.kept[i])
+	}
+	s.kept = append(kept, expr)
+}
+
+// keep добавляет выражение к формулам, участвующим в проверке поглощения.
+// Возвращает false, если выражение поглощено уже сохранённой формулой.
+func (s *Solver) keep(expr expression.Expression) bool {
+	if !s.subsumption {
+		return true
+	}
+
+	if s.isSubsumed(expr) {
+		return false
+	}
+	s.retireSubsumedBy(expr)
+	return true
+}
+
+// dropRetired удаляет исключённые формулы из списка известных.
+func (s *Solver) dropRetired() {
+	if !s.subsumption || s.retired.IsEmpty() {
+		return
+	}
+
+	axioms := s.axioms[:0]
+	for i := range s.axioms {
+		if !s.retired.Has(s.axioms[i].String()) {
+			axioms = append(axioms, s.axioms[i])
+		}
+	}
+	s.axioms = axioms
+}